@@ -0,0 +1,109 @@
+package geom
+
+import "fmt"
+
+// The Alignment type represents a relative position in a rectangle, where X and
+// Y are fractions of its width and height. {0, 0} is the top-left corner and
+// {1, 1} is the bottom-right corner of the rectangle.
+//
+// Alignments are used to position rectangles inside others, and as the anchor
+// points which stay fixed when rectangles are resized.
+type Alignment struct {
+	X float64
+	Y float64
+}
+
+var (
+	// AlignTopLeft is the top-left corner of a rectangle.
+	AlignTopLeft = Alignment{0, 0}
+
+	// AlignTop is the middle of the top edge of a rectangle.
+	AlignTop = Alignment{0.5, 0}
+
+	// AlignTopRight is the top-right corner of a rectangle.
+	AlignTopRight = Alignment{1, 0}
+
+	// AlignLeft is the middle of the left edge of a rectangle.
+	AlignLeft = Alignment{0, 0.5}
+
+	// AlignCenter is the center of a rectangle.
+	AlignCenter = Alignment{0.5, 0.5}
+
+	// AlignRight is the middle of the right edge of a rectangle.
+	AlignRight = Alignment{1, 0.5}
+
+	// AlignBottomLeft is the bottom-left corner of a rectangle.
+	AlignBottomLeft = Alignment{0, 1}
+
+	// AlignBottom is the middle of the bottom edge of a rectangle.
+	AlignBottom = Alignment{0.5, 1}
+
+	// AlignBottomRight is the bottom-right corner of a rectangle.
+	AlignBottomRight = Alignment{1, 1}
+)
+
+// The String method returns a human-readable representation of the alignment,
+// which is the name of the anchor point for the nine predefined alignments.
+func (a Alignment) String() string {
+	switch a {
+	case AlignTopLeft:
+		return "top-left"
+	case AlignTop:
+		return "top"
+	case AlignTopRight:
+		return "top-right"
+	case AlignLeft:
+		return "left"
+	case AlignCenter:
+		return "center"
+	case AlignRight:
+		return "right"
+	case AlignBottomLeft:
+		return "bottom-left"
+	case AlignBottom:
+		return "bottom"
+	case AlignBottomRight:
+		return "bottom-right"
+	default:
+		return fmt.Sprintf("alignment { x = %g, y = %g }", a.X, a.Y)
+	}
+}
+
+// Anchor returns the point of the rectangle at the relative position given by
+// the alignment.
+func (r Rect) Anchor(a Alignment) Point {
+	return Point{
+		X: r.X + (r.W * a.X),
+		Y: r.Y + (r.H * a.Y),
+	}
+}
+
+// Resize returns a rectangle of the given size, positioned so that the anchor
+// point given by the alignment is at the same position as on the rectangle the
+// method is called on.
+//
+// For example, resizing with AlignBottomRight keeps the bottom-right corner
+// fixed, and resizing with AlignCenter keeps the center of the rectangle.
+func (r Rect) Resize(s Size, a Alignment) Rect {
+	return Rect{
+		X: r.X + ((r.W - s.W) * a.X),
+		Y: r.Y + ((r.H - s.H) * a.Y),
+		W: s.W,
+		H: s.H,
+	}
+}
+
+// AlignRect returns the `inner` rectangle moved to the position given by the
+// alignment in the `outer` rectangle, the anchor point of the alignment on the
+// inner rectangle is placed on the same anchor point of the outer rectangle.
+//
+// The result is the same as CenterRect when the alignment is AlignCenter.
+func AlignRect(outer Rect, inner Rect, a Alignment) Rect {
+	return AlignSize(outer, inner.Size(), a)
+}
+
+// AlignSize returns a rectangle of the given size placed at the position given
+// by the alignment in the `outer` rectangle.
+func AlignSize(outer Rect, inner Size, a Alignment) Rect {
+	return outer.Resize(inner, a)
+}
@@ -0,0 +1,107 @@
+package geom
+
+import "testing"
+
+func TestAlignmentString(t *testing.T) {
+	tests := []struct {
+		align Alignment
+		str   string
+	}{
+		{AlignTopLeft, "top-left"},
+		{AlignTop, "top"},
+		{AlignTopRight, "top-right"},
+		{AlignLeft, "left"},
+		{AlignCenter, "center"},
+		{AlignRight, "right"},
+		{AlignBottomLeft, "bottom-left"},
+		{AlignBottom, "bottom"},
+		{AlignBottomRight, "bottom-right"},
+		{Alignment{0.25, 0.75}, "alignment { x = 0.25, y = 0.75 }"},
+	}
+
+	for _, test := range tests {
+		if s := test.align.String(); s != test.str {
+			t.Errorf("%s != %s", s, test.str)
+		}
+	}
+}
+
+func TestRectAnchor(t *testing.T) {
+	r := Rect{10, 20, 100, 50}
+
+	tests := []struct {
+		align Alignment
+		point Point
+	}{
+		{AlignTopLeft, Point{10, 20}},
+		{AlignTop, Point{60, 20}},
+		{AlignTopRight, Point{110, 20}},
+		{AlignLeft, Point{10, 45}},
+		{AlignCenter, r.Center()},
+		{AlignRight, Point{110, 45}},
+		{AlignBottomLeft, Point{10, 70}},
+		{AlignBottom, Point{60, 70}},
+		{AlignBottomRight, r.Tip()},
+		{Alignment{0.1, 0.2}, Point{20, 30}},
+	}
+
+	for _, test := range tests {
+		if p := r.Anchor(test.align); p != test.point {
+			t.Errorf("%s: %s != %s", test.align, p, test.point)
+		}
+	}
+}
+
+func TestRectResize(t *testing.T) {
+	r := Rect{10, 20, 100, 50}
+	s := Size{20, 10}
+
+	tests := []struct {
+		align Alignment
+		rect  Rect
+	}{
+		{AlignTopLeft, Rect{10, 20, 20, 10}},
+		{AlignCenter, Rect{50, 40, 20, 10}},
+		{AlignBottomRight, Rect{90, 60, 20, 10}},
+		{AlignTop, Rect{50, 20, 20, 10}},
+		{AlignLeft, Rect{10, 40, 20, 10}},
+	}
+
+	for _, test := range tests {
+		x := r.Resize(s, test.align)
+
+		if x != test.rect {
+			t.Errorf("%s: %s != %s", test.align, x, test.rect)
+		}
+
+		if p0, p1 := r.Anchor(test.align), x.Anchor(test.align); p0 != p1 {
+			t.Errorf("%s: anchor moved from %s to %s", test.align, p0, p1)
+		}
+	}
+}
+
+func TestAlignRect(t *testing.T) {
+	outer := Rect{0, 0, 10, 10}
+	inner := Rect{-5, 3, 2, 2}
+
+	tests := []struct {
+		align Alignment
+		rect  Rect
+	}{
+		{AlignTopLeft, Rect{0, 0, 2, 2}},
+		{AlignTopRight, Rect{8, 0, 2, 2}},
+		{AlignCenter, CenterRect(outer, inner)},
+		{AlignBottom, Rect{4, 8, 2, 2}},
+		{Alignment{0.25, 0.5}, Rect{2, 4, 2, 2}},
+	}
+
+	for _, test := range tests {
+		if r := AlignRect(outer, inner, test.align); r != test.rect {
+			t.Errorf("AlignRect %s: %s != %s", test.align, r, test.rect)
+		}
+
+		if r := AlignSize(outer, inner.Size(), test.align); r != test.rect {
+			t.Errorf("AlignSize %s: %s != %s", test.align, r, test.rect)
+		}
+	}
+}
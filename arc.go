@@ -0,0 +1,160 @@
+package geom
+
+import "math"
+
+// EllipticalArcTo appends path elements that draw an elliptical arc from the
+// current path position to pt, using the endpoint parameterization of SVG arcs.
+//
+// The radii of the ellipse are given by r, rotation is the angle in radians of
+// the x axis of the ellipse, and the large and sweep flags select which of the
+// four candidate arcs is drawn: large selects the arc spanning more than 180
+// degrees, and sweep the arc going in the direction of increasing angles.
+//
+// Like in SVG, radii too small to reach pt are scaled up, and a straight line
+// is drawn when one of the radii is zero. The arc is approximated by cubic
+// curves spanning at most a quarter of the ellipse each, which keeps the error
+// below 0.03% of the radii.
+func (p *Path) EllipticalArcTo(r Size, rotation float64, large bool, sweep bool, pt Point) {
+	p.ensureStartWithMoveTo()
+	appendArc(p, p.LastPoint(), r, rotation, large, sweep, pt)
+}
+
+// ArcTo appends path elements that draw an arc of the given radius which is
+// tangent to both the line going from the current path position to p1, and the
+// line going from p1 to p2, behaving like the arcTo method of HTML canvas.
+//
+// A straight line is drawn from the current path position to the start of the
+// arc, which ends at the tangent point on the line between p1 and p2. When two
+// of the points are equal, the points are collinear or the radius is zero, a
+// straight line to p1 is drawn instead. Negative radii are treated as positive
+// values.
+func (p *Path) ArcTo(p1 Point, p2 Point, radius float64) {
+	p.ensureStartWithMoveTo()
+	p0 := p.LastPoint()
+	radius = math.Abs(radius)
+	u0 := p0.Sub(p1).Normalize()
+	u2 := p2.Sub(p1).Normalize()
+	cross := u0.Cross(u2)
+
+	if radius == 0 || math.Abs(cross) < 1e-12 {
+		p.LineTo(p1)
+		return
+	}
+
+	// The arc touches both lines at the distance d from p1, and its center is
+	// on the bisector of the angle formed by the lines.
+	angle := math.Acos(math.Max(-1, math.Min(1, u0.Dot(u2))))
+	d := radius / math.Tan(angle/2)
+	t0 := p1.Add(u0.Scale(d))
+	t2 := p1.Add(u2.Scale(d))
+	center := p1.Add(u0.Add(u2).Normalize().Scale(radius / math.Sin(angle/2)))
+
+	if t0 != p0 {
+		p.LineTo(t0)
+	}
+
+	a0 := t0.Sub(center)
+	appendEllipseSegments(p, center, Size{radius, radius}, 0, a0.Angle(), a0.AngleTo(t2.Sub(center)), t2)
+}
+
+// appendArc appends to path cubic curves approximating the elliptical arc going
+// from p0 to p1, using the endpoint parameterization of SVG arcs.
+//
+// The radii of the ellipse are given by r, phi is the rotation of the x axis of
+// the ellipse in radians, and the large and sweep flags select which of the
+// four candidate arcs is drawn.
+func appendArc(path *Path, p0 Point, r Size, phi float64, large bool, sweep bool, p1 Point) {
+	rx, ry := math.Abs(r.W), math.Abs(r.H)
+
+	if p0 == p1 {
+		return
+	}
+
+	if rx == 0 || ry == 0 {
+		path.LineTo(p1)
+		return
+	}
+
+	// Conversion from endpoint to center parameterization, see section F.6.5
+	// of the SVG 1.1 specification.
+	sin, cos := math.Sincos(phi)
+	dx, dy := (p0.X-p1.X)/2, (p0.Y-p1.Y)/2
+	x1 := cos*dx + sin*dy
+	y1 := -sin*dx + cos*dy
+
+	// Radii that are too small to reach the end point are scaled up.
+	if l := (x1*x1)/(rx*rx) + (y1*y1)/(ry*ry); l > 1 {
+		l = math.Sqrt(l)
+		rx *= l
+		ry *= l
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	k := math.Sqrt(math.Max(0, num/den))
+
+	if large == sweep {
+		k = -k
+	}
+
+	cx1 := k * rx * y1 / ry
+	cy1 := -k * ry * x1 / rx
+
+	center := Point{
+		X: cos*cx1 - sin*cy1 + (p0.X+p1.X)/2,
+		Y: sin*cx1 + cos*cy1 + (p0.Y+p1.Y)/2,
+	}
+
+	u := Point{(x1 - cx1) / rx, (y1 - cy1) / ry}
+	v := Point{(-x1 - cx1) / rx, (-y1 - cy1) / ry}
+	theta := Point{1, 0}.AngleTo(u)
+	delta := u.AngleTo(v)
+
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	appendEllipseSegments(path, center, Size{rx, ry}, phi, theta, delta, p1)
+}
+
+// appendEllipseSegments appends to path cubic curves approximating the arc of
+// the ellipse with the given center, radii and rotation, starting at angle theta
+// and sweeping by delta radians. The arc is split in segments of at most a
+// quarter turn, and the last segment ends exactly at end.
+func appendEllipseSegments(path *Path, center Point, r Size, phi float64, theta float64, delta float64, end Point) {
+	n := int(math.Ceil(math.Abs(delta)/(math.Pi/2) - 1e-9))
+
+	if n < 1 {
+		n = 1
+	}
+
+	step := delta / float64(n)
+	k := 4.0 / 3.0 * math.Tan(step/4)
+	sin, cos := math.Sincos(phi)
+
+	ellipse := func(a float64) (Point, Point) {
+		sa, ca := math.Sincos(a)
+		pt := Point{r.W * ca, r.H * sa}
+		d := Point{-r.W * sa, r.H * ca}
+		pt = Point{cos*pt.X - sin*pt.Y + center.X, sin*pt.X + cos*pt.Y + center.Y}
+		d = Point{cos*d.X - sin*d.Y, sin*d.X + cos*d.Y}
+		return pt, d
+	}
+
+	a0 := theta
+	p0, d0 := ellipse(a0)
+
+	for i := 0; i < n; i++ {
+		a1 := a0 + step
+		p1, d1 := ellipse(a1)
+
+		if i == n-1 {
+			p1 = end
+		}
+
+		path.CubicCurveTo(p0.Add(d0.Scale(k)), p1.Sub(d1.Scale(k)), p1)
+		a0, p0, d0 = a1, p1, d1
+	}
+}
@@ -0,0 +1,160 @@
+package geom
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// checkArc verifies that every curve of the path after the element at index n
+// is on the circle of the given center and radius.
+func checkArc(t *testing.T, p Path, n int, center Point, radius float64) {
+	t.Helper()
+	arc := Path{}
+	arc.MoveTo(p.lastPointAt(n))
+	arc.Elements = append(arc.Elements, p.Elements[n+1:]...)
+
+	for _, c := range contours(arc) {
+		for _, cv := range c.curves {
+			for i := 0; i <= 8; i++ {
+				pt := cv.point(float64(i) / 8)
+
+				if d := pt.Distance(center); math.Abs(d-radius) > 3e-4*radius {
+					t.Errorf("point too far from the arc: %v (%g)", pt, d)
+				}
+			}
+		}
+	}
+}
+
+func TestPathEllipticalArcTo(t *testing.T) {
+	tests := []struct {
+		large  bool
+		sweep  bool
+		middle Point
+	}{
+		{false, true, Point{5, -5}},
+		{false, false, Point{5, 5}},
+		{true, true, Point{5, -5}},
+		{true, false, Point{5, 5}},
+	}
+
+	for _, test := range tests {
+		p := Path{}
+		p.MoveTo(Point{0, 0})
+		p.EllipticalArcTo(Size{5, 5}, 0, test.large, test.sweep, Point{10, 0})
+
+		if n := len(p.Elements); n != 3 {
+			t.Errorf("large=%t sweep=%t: invalid number of elements: %v", test.large, test.sweep, p)
+			continue
+		}
+
+		if !pointsNearlyEqual(p.Elements[1].Points[2], test.middle) {
+			t.Errorf("large=%t sweep=%t: invalid middle of the arc: %v", test.large, test.sweep, p.Elements[1].Points[2])
+		}
+
+		if p.LastPoint() != (Point{10, 0}) {
+			t.Errorf("large=%t sweep=%t: invalid end of the arc: %v", test.large, test.sweep, p.LastPoint())
+		}
+
+		checkArc(t, p, 0, Point{5, 0}, 5)
+	}
+}
+
+func TestPathEllipticalArcToRotated(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.EllipticalArcTo(Size{10, 5}, math.Pi/2, false, true, Point{0, 20})
+
+	// The x axis of the ellipse is vertical, the arc is half of the ellipse
+	// going through (5, 10).
+	if b := p.Bounds(); !rectsNearlyEqual(b, Rect{0, 0, 5, 20}) {
+		t.Error("invalid bounds of the arc:", b)
+	}
+}
+
+func TestPathEllipticalArcToDegenerate(t *testing.T) {
+	p := Path{}
+	p.EllipticalArcTo(Size{0, 5}, 0, false, false, Point{10, 0})
+
+	r := Path{}
+	r.MoveTo(Point{})
+	r.LineTo(Point{10, 0})
+
+	if !reflect.DeepEqual(p, r) {
+		t.Error("a zero radius must produce a line:", p)
+	}
+
+	p = Path{}
+	p.MoveTo(Point{1, 1})
+	p.EllipticalArcTo(Size{5, 5}, 0, false, false, Point{1, 1})
+
+	if n := len(p.Elements); n != 1 {
+		t.Error("an arc ending at the current position must not be drawn:", p)
+	}
+}
+
+func TestPathArcTo(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.ArcTo(Point{10, 0}, Point{10, 10}, 2)
+
+	if n := len(p.Elements); n != 3 || p.Elements[1].Type != LineTo || p.Elements[2].Type != CubicCurveTo {
+		t.Fatal("invalid arc:", p)
+	}
+
+	if pt := p.Elements[1].Points[0]; !pointsNearlyEqual(pt, Point{8, 0}) {
+		t.Error("invalid start of the arc:", pt)
+	}
+
+	if pt := p.LastPoint(); !pointsNearlyEqual(pt, Point{10, 2}) {
+		t.Error("invalid end of the arc:", pt)
+	}
+
+	checkArc(t, p, 1, Point{8, 2}, 2)
+}
+
+func TestPathArcToObtuse(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.ArcTo(Point{10, 0}, Point{20, -10}, 5)
+
+	// The angle between the lines is 135 degrees, the tangent points are at
+	// r * tan(22.5 degrees) from the corner.
+	d := 5 * math.Tan(math.Pi/8)
+	start := Point{10 - d, 0}
+	end := Point{10 + d/math.Sqrt2, -d / math.Sqrt2}
+
+	if pt := p.Elements[1].Points[0]; !pointsNearlyEqual(pt, start) {
+		t.Error("invalid start of the arc:", pt)
+	}
+
+	if pt := p.LastPoint(); !pointsNearlyEqual(pt, end) {
+		t.Error("invalid end of the arc:", pt)
+	}
+
+	checkArc(t, p, 1, Point{10 - d, -5}, 5)
+}
+
+func TestPathArcToLine(t *testing.T) {
+	tests := []struct {
+		p1     Point
+		p2     Point
+		radius float64
+	}{
+		{Point{10, 0}, Point{20, 0}, 5},
+		{Point{10, 0}, Point{10, 0}, 5},
+		{Point{0, 0}, Point{10, 10}, 5},
+		{Point{10, 0}, Point{10, 10}, 0},
+	}
+
+	for _, test := range tests {
+		p := Path{}
+		p.MoveTo(Point{0, 0})
+		p.ArcTo(test.p1, test.p2, test.radius)
+
+		if n := len(p.Elements); n != 2 || p.Elements[1].Type != LineTo || p.LastPoint() != test.p1 {
+			t.Errorf("ArcTo(%v, %v, %g): %v", test.p1, test.p2, test.radius, p)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package geom
+
+import "math"
+
+// This file contains helper functions to evaluate, split and analyze the
+// quadratic and cubic Bézier curves that path elements are made of.
+
+func quadPoint(p0 Point, p1 Point, p2 Point, t float64) Point {
+	u := 1 - t
+	a := u * u
+	b := 2 * u * t
+	c := t * t
+	return Point{
+		X: a*p0.X + b*p1.X + c*p2.X,
+		Y: a*p0.Y + b*p1.Y + c*p2.Y,
+	}
+}
+
+func cubicPoint(p0 Point, p1 Point, p2 Point, p3 Point, t float64) Point {
+	u := 1 - t
+	a := u * u * u
+	b := 3 * u * u * t
+	c := 3 * u * t * t
+	d := t * t * t
+	return Point{
+		X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+		Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+	}
+}
+
+// quadExtrema appends to ts the parameters in (0, 1) where the derivative of
+// the one-dimensional quadratic curve defined by a, b and c is zero.
+func quadExtrema(ts []float64, a float64, b float64, c float64) []float64 {
+	// B'(t) = 2 * ((b - a) + t * (a - 2b + c))
+	if d := a - 2*b + c; d != 0 {
+		if t := (a - b) / d; t > 0 && t < 1 {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+// cubicExtrema appends to ts the parameters in (0, 1) where the derivative of
+// the one-dimensional cubic curve defined by a, b, c and d is zero.
+func cubicExtrema(ts []float64, a float64, b float64, c float64, d float64) []float64 {
+	// B'(t) = 3 * (qa*t^2 + qb*t + qc)
+	qa := -a + 3*b - 3*c + d
+	qb := 2 * (a - 2*b + c)
+	qc := b - a
+
+	var roots [2]float64
+
+	for _, t := range solveQuadratic(roots[:0], qa, qb, qc) {
+		if t > 0 && t < 1 {
+			ts = append(ts, t)
+		}
+	}
+
+	return ts
+}
+
+// solveQuadratic appends to roots the real solutions of a*x^2 + b*x + c = 0.
+func solveQuadratic(roots []float64, a float64, b float64, c float64) []float64 {
+	if math.Abs(a) < 1e-12 {
+		if b != 0 {
+			roots = append(roots, -c/b)
+		}
+		return roots
+	}
+
+	delta := b*b - 4*a*c
+
+	switch {
+	case delta < 0:
+	case delta == 0:
+		roots = append(roots, -b/(2*a))
+	default:
+		// Numerically stable form that avoids cancellation between -b and the
+		// square root of the discriminant.
+		q := -0.5 * (b + math.Copysign(math.Sqrt(delta), b))
+		roots = append(roots, q/a, c/q)
+	}
+
+	return roots
+}
+
+// splitQuad splits the quadratic curve defined by p at the parameter t and
+// returns the control points of the two resulting curves.
+func splitQuad(p [3]Point, t float64) (a [3]Point, b [3]Point) {
+	p01 := p[0].Lerp(p[1], t)
+	p12 := p[1].Lerp(p[2], t)
+	m := p01.Lerp(p12, t)
+	a = [3]Point{p[0], p01, m}
+	b = [3]Point{m, p12, p[2]}
+	return
+}
+
+// splitCubic splits the cubic curve defined by p at the parameter t and returns
+// the control points of the two resulting curves.
+func splitCubic(p [4]Point, t float64) (a [4]Point, b [4]Point) {
+	p01 := p[0].Lerp(p[1], t)
+	p12 := p[1].Lerp(p[2], t)
+	p23 := p[2].Lerp(p[3], t)
+	p012 := p01.Lerp(p12, t)
+	p123 := p12.Lerp(p23, t)
+	m := p012.Lerp(p123, t)
+	a = [4]Point{p[0], p01, p012, m}
+	b = [4]Point{m, p123, p23, p[3]}
+	return
+}
+
+// distanceToSegment returns the distance between p and the closest point of
+// the line segment going from a to b.
+func distanceToSegment(p Point, a Point, b Point) float64 {
+	d := b.Sub(a)
+
+	if l := d.Dot(d); l != 0 {
+		t := math.Max(0, math.Min(1, p.Sub(a).Dot(d)/l))
+		return p.Distance(a.Lerp(b, t))
+	}
+
+	return p.Distance(a)
+}
+
+// maxSubdivisions bounds the recursion depth of the adaptive subdivision of
+// curves, which protects against degenerate input like infinite coordinates.
+const maxSubdivisions = 16
+
+// flattenQuad appends to points the end points of line segments approximating
+// the quadratic curve p, the start point of the curve isn't appended.
+func flattenQuad(points []Point, p [3]Point, tolerance float64, depth int) []Point {
+	if depth >= maxSubdivisions || distanceToSegment(p[1], p[0], p[2]) <= tolerance {
+		return append(points, p[2])
+	}
+	a, b := splitQuad(p, 0.5)
+	points = flattenQuad(points, a, tolerance, depth+1)
+	return flattenQuad(points, b, tolerance, depth+1)
+}
+
+// flattenCubic appends to points the end points of line segments approximating
+// the cubic curve p, the start point of the curve isn't appended.
+func flattenCubic(points []Point, p [4]Point, tolerance float64, depth int) []Point {
+	if depth >= maxSubdivisions || math.Max(distanceToSegment(p[1], p[0], p[3]), distanceToSegment(p[2], p[0], p[3])) <= tolerance {
+		return append(points, p[3])
+	}
+	a, b := splitCubic(p, 0.5)
+	points = flattenCubic(points, a, tolerance, depth+1)
+	return flattenCubic(points, b, tolerance, depth+1)
+}
+
+// cubicDerivative returns the derivative of the cubic curve p at parameter t.
+func cubicDerivative(p [4]Point, t float64) Point {
+	u := 1 - t
+	a := 3 * u * u
+	b := 6 * u * t
+	c := 3 * t * t
+	return Point{
+		X: a*(p[1].X-p[0].X) + b*(p[2].X-p[1].X) + c*(p[3].X-p[2].X),
+		Y: a*(p[1].Y-p[0].Y) + b*(p[2].Y-p[1].Y) + c*(p[3].Y-p[2].Y),
+	}
+}
+
+// quadToCubic returns the control points of the cubic curve representing the
+// same shape as the quadratic curve p.
+func quadToCubic(p [3]Point) [4]Point {
+	return [4]Point{
+		p[0],
+		p[0].Lerp(p[1], 2.0/3.0),
+		p[2].Lerp(p[1], 2.0/3.0),
+		p[2],
+	}
+}
@@ -0,0 +1,39 @@
+package geom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSolveQuadratic(t *testing.T) {
+	tests := []struct {
+		a     float64
+		b     float64
+		c     float64
+		roots []float64
+	}{
+		{1, -3, 2, []float64{2, 1}},
+		{1, 2, 1, []float64{-1}},
+		{1, 0, 1, []float64{}},
+		{0, 2, -1, []float64{0.5}},
+		{0, 0, 1, []float64{}},
+	}
+
+	for _, test := range tests {
+		if roots := solveQuadratic([]float64{}, test.a, test.b, test.c); !reflect.DeepEqual(roots, test.roots) {
+			t.Errorf("solveQuadratic(%g, %g, %g): %v", test.a, test.b, test.c, roots)
+		}
+	}
+}
+
+func TestQuadPoint(t *testing.T) {
+	if p := quadPoint(Point{0, 0}, Point{1, 2}, Point{2, 0}, 0.5); p != (Point{1, 1}) {
+		t.Error("invalid point on quadratic curve:", p)
+	}
+}
+
+func TestCubicPoint(t *testing.T) {
+	if p := cubicPoint(Point{0, 0}, Point{0, 4}, Point{2, 4}, Point{2, 0}, 0.5); p != (Point{1, 3}) {
+		t.Error("invalid point on cubic curve:", p)
+	}
+}
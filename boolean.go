@@ -0,0 +1,339 @@
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+// PathOp is an enumeration of the boolean operations that can be applied to
+// combine shapes.
+type PathOp int
+
+const (
+	// Union is the operation producing the area covered by either shape.
+	Union PathOp = iota
+
+	// Intersection is the operation producing the area covered by both
+	// shapes.
+	Intersection
+
+	// Difference is the operation producing the area covered by the first
+	// shape but not the second one.
+	Difference
+
+	// Xor is the operation producing the area covered by exactly one of the
+	// shapes.
+	Xor
+)
+
+// The String method returns a human-readable representation of the operation.
+func (op PathOp) String() string {
+	switch op {
+	case Union:
+		return "union"
+	case Intersection:
+		return "intersection"
+	case Difference:
+		return "difference"
+	case Xor:
+		return "xor"
+	default:
+		return "unknown"
+	}
+}
+
+func (op PathOp) apply(a bool, b bool) bool {
+	switch op {
+	case Union:
+		return a || b
+	case Intersection:
+		return a && b
+	case Difference:
+		return a && !b
+	default:
+		return a != b
+	}
+}
+
+// CombinePaths applies a boolean operation to the two shapes given as
+// arguments and returns the resulting path. The areas covered by each shape are
+// determined by the fill rule, with every sub-path being implicitly closed.
+//
+// Curves are approximated by line segments using the tolerance argument as
+// maximum distance, which means the returned path is made of MoveTo, LineTo and
+// ClosePath elements only. Its sub-paths don't intersect each other, and are
+// oriented so that it can be filled with either fill rule. When the tolerance
+// is zero or negative, one thousandth of the largest dimension of the shapes is
+// used.
+func CombinePaths(op PathOp, a Shape, b Shape, rule FillRule, tolerance float64) Path {
+	pa := a.Path()
+	pb := b.Path()
+
+	if tolerance <= 0 {
+		ba, bb := pa.Bounds(), pb.Bounds()
+		tolerance = defaultTolerance(Rect{W: math.Max(ba.W, bb.W), H: math.Max(ba.H, bb.H)})
+	}
+
+	pa = pa.Flatten(tolerance)
+	pb = pb.Flatten(tolerance)
+
+	c := combiner{
+		snap: tolerance / 1000,
+	}
+
+	c.addPolylines(pa.Polylines(tolerance))
+	c.addPolylines(pb.Polylines(tolerance))
+	c.split()
+
+	inside := func(pt Point) bool {
+		return op.apply(pa.Contains(pt, rule), pb.Contains(pt, rule))
+	}
+
+	return c.build(inside)
+}
+
+type combiner struct {
+	snap float64
+	segs []combinerSegment
+}
+
+type combinerSegment struct {
+	a      Point
+	b      Point
+	splits []float64
+}
+
+func (c *combiner) addPolylines(lines [][]Point) {
+	for _, line := range lines {
+		// Polylines of open sub-paths are closed implicitly when filled.
+		if first, last := line[0], line[len(line)-1]; first != last {
+			line = append(line, first)
+		}
+
+		for i := 1; i < len(line); i++ {
+			if line[i-1] != line[i] {
+				c.segs = append(c.segs, combinerSegment{a: line[i-1], b: line[i]})
+			}
+		}
+	}
+}
+
+// split finds the intersections between every pair of segments, and records
+// the parameters where each segment must be split.
+func (c *combiner) split() {
+	for i := range c.segs {
+		s1 := &c.segs[i]
+
+		for j := i + 1; j < len(c.segs); j++ {
+			s2 := &c.segs[j]
+
+			if !segmentBoundsOverlap(s1, s2) {
+				continue
+			}
+
+			d1 := s1.b.Sub(s1.a)
+			d2 := s2.b.Sub(s2.a)
+			d := s2.a.Sub(s1.a)
+			den := d1.Cross(d2)
+
+			if math.Abs(den) > 1e-12*d1.Length()*d2.Length() {
+				t := d.Cross(d2) / den
+				u := d.Cross(d1) / den
+
+				if t >= 0 && t <= 1 && u >= 0 && u <= 1 {
+					s1.addSplit(t)
+					s2.addSplit(u)
+				}
+				continue
+			}
+
+			// The segments are parallel, when they are also collinear the
+			// end points of each segment that lie on the other one split it.
+			if math.Abs(d.Cross(d1)) > c.snap*d1.Length() {
+				continue
+			}
+
+			l1 := d1.Dot(d1)
+			l2 := d2.Dot(d2)
+			s1.addSplit(s2.a.Sub(s1.a).Dot(d1) / l1)
+			s1.addSplit(s2.b.Sub(s1.a).Dot(d1) / l1)
+			s2.addSplit(s1.a.Sub(s2.a).Dot(d2) / l2)
+			s2.addSplit(s1.b.Sub(s2.a).Dot(d2) / l2)
+		}
+	}
+}
+
+func segmentBoundsOverlap(s1 *combinerSegment, s2 *combinerSegment) bool {
+	return math.Min(s1.a.X, s1.b.X) <= math.Max(s2.a.X, s2.b.X) &&
+		math.Min(s2.a.X, s2.b.X) <= math.Max(s1.a.X, s1.b.X) &&
+		math.Min(s1.a.Y, s1.b.Y) <= math.Max(s2.a.Y, s2.b.Y) &&
+		math.Min(s2.a.Y, s2.b.Y) <= math.Max(s1.a.Y, s1.b.Y)
+}
+
+func (s *combinerSegment) addSplit(t float64) {
+	if t > 0 && t < 1 {
+		s.splits = append(s.splits, t)
+	}
+}
+
+func (c *combiner) snapPoint(p Point) Point {
+	if c.snap <= 0 {
+		return p
+	}
+	return Point{
+		X: math.Floor(p.X/c.snap+0.5) * c.snap,
+		Y: math.Floor(p.Y/c.snap+0.5) * c.snap,
+	}
+}
+
+// build classifies the edges produced by splitting the segments, keeping only
+// those that separate an inside area from an outside area, then chains them
+// into closed sub-paths.
+func (c *combiner) build(inside func(Point) bool) Path {
+	// Edges are indexed by their end points, so duplicate edges (typically
+	// when the shapes share part of their boundaries) are only kept once.
+	seen := make(map[[2]Point]bool)
+	outgoing := make(map[Point][]Point)
+	count := 0
+
+	for _, s := range c.segs {
+		sort.Float64s(s.splits)
+		s.splits = append(s.splits, 1)
+
+		t0 := 0.0
+		prev := c.snapPoint(s.a)
+		normal := s.b.Sub(s.a).Perp().Normalize()
+
+		for _, t1 := range s.splits {
+			next := c.snapPoint(s.a.Lerp(s.b, t1))
+
+			if prev == next {
+				continue
+			}
+
+			key := [2]Point{prev, next}
+
+			if prev.X > next.X || (prev.X == next.X && prev.Y > next.Y) {
+				key = [2]Point{next, prev}
+			}
+
+			if !seen[key] {
+				seen[key] = true
+
+				// The classification uses the original segment rather than
+				// the snapped edge, so the points tested on each side of the
+				// edge can be much closer than the snapping distance.
+				m := s.a.Lerp(s.b, (t0+t1)/2)
+				n := normal.Scale(math.Min(next.Distance(prev)/4, c.snap))
+				left := inside(m.Add(n))
+				right := inside(m.Sub(n))
+
+				switch {
+				case left && !right:
+					outgoing[prev] = append(outgoing[prev], next)
+					count++
+				case right && !left:
+					outgoing[next] = append(outgoing[next], prev)
+					count++
+				}
+			}
+
+			t0, prev = t1, next
+		}
+	}
+
+	return chainEdges(outgoing, count)
+}
+
+// chainEdges connects the directed edges into closed sub-paths. When multiple
+// edges leave the same vertex the one turning the most to the left is chosen,
+// which keeps areas that only touch at a vertex in separate sub-paths.
+func chainEdges(outgoing map[Point][]Point, count int) Path {
+	out := MakePath(count + 2)
+
+	// Iterating over a map is not deterministic, sorting the start vertices
+	// makes sure that the same input always produces the same path.
+	starts := make([]Point, 0, len(outgoing))
+
+	for p := range outgoing {
+		starts = append(starts, p)
+	}
+
+	sort.Slice(starts, func(i int, j int) bool {
+		if starts[i].Y != starts[j].Y {
+			return starts[i].Y < starts[j].Y
+		}
+		return starts[i].X < starts[j].X
+	})
+
+	for _, start := range starts {
+		for len(outgoing[start]) != 0 {
+			loop := []Point{start}
+			prev, cur := start, start
+
+			for {
+				next, ok := takeEdge(outgoing, prev, cur, cur == start && len(loop) == 1)
+
+				if !ok || next == start {
+					break
+				}
+
+				loop = append(loop, next)
+				prev, cur = cur, next
+			}
+
+			if loop = simplifyLoop(loop); len(loop) >= 3 {
+				out = AppendPolygon(out, loop...)
+			}
+		}
+	}
+
+	return out
+}
+
+func takeEdge(outgoing map[Point][]Point, prev Point, cur Point, first bool) (Point, bool) {
+	edges := outgoing[cur]
+
+	if len(edges) == 0 {
+		return Point{}, false
+	}
+
+	best := 0
+
+	if !first {
+		din := cur.Sub(prev)
+		bestAngle := math.Inf(-1)
+
+		for i, next := range edges {
+			if a := din.AngleTo(next.Sub(cur)); a > bestAngle {
+				best, bestAngle = i, a
+			}
+		}
+	}
+
+	next := edges[best]
+	edges[best] = edges[len(edges)-1]
+	outgoing[cur] = edges[:len(edges)-1]
+	return next, true
+}
+
+// simplifyLoop removes the vertices of a closed polygon that are in the middle
+// of two collinear edges.
+func simplifyLoop(loop []Point) []Point {
+	out := loop[:0:0]
+
+	for i, p := range loop {
+		prev := loop[(i+len(loop)-1)%len(loop)]
+		next := loop[(i+1)%len(loop)]
+		d1 := p.Sub(prev)
+		d2 := next.Sub(p)
+
+		if math.Abs(d1.Cross(d2)) <= 1e-12*d1.Length()*d2.Length() && d1.Dot(d2) > 0 {
+			continue
+		}
+
+		out = append(out, p)
+	}
+
+	return out
+}
@@ -0,0 +1,206 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+// pathArea computes the area covered by a path made of closed polygons which
+// don't intersect each other, using the shoelace formula.
+func pathArea(p Path) float64 {
+	area := 0.0
+
+	for _, line := range p.Polylines(0.001) {
+		for i := 1; i < len(line); i++ {
+			area += line[i-1].Cross(line[i])
+		}
+	}
+
+	return math.Abs(area / 2)
+}
+
+func TestPathOpString(t *testing.T) {
+	tests := []struct {
+		op  PathOp
+		str string
+	}{
+		{Union, "union"},
+		{Intersection, "intersection"},
+		{Difference, "difference"},
+		{Xor, "xor"},
+		{PathOp(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		if s := test.op.String(); s != test.str {
+			t.Errorf("invalid string representation of path operation %d: %s", test.op, s)
+		}
+	}
+}
+
+func TestCombinePathsRects(t *testing.T) {
+	a := Rect{0, 0, 2, 2}
+	b := Rect{1, 1, 2, 2}
+
+	tests := []struct {
+		op   PathOp
+		area float64
+		in   []Point
+		out  []Point
+	}{
+		{Union, 7, []Point{{0.5, 0.5}, {1.5, 1.5}, {2.5, 2.5}}, []Point{{2.5, 0.5}, {0.5, 2.5}}},
+		{Intersection, 1, []Point{{1.5, 1.5}}, []Point{{0.5, 0.5}, {2.5, 2.5}}},
+		{Difference, 3, []Point{{0.5, 0.5}, {1.5, 0.5}}, []Point{{1.5, 1.5}, {2.5, 2.5}}},
+		{Xor, 6, []Point{{0.5, 0.5}, {2.5, 2.5}}, []Point{{1.5, 1.5}, {2.5, 0.5}}},
+	}
+
+	for _, test := range tests {
+		p := CombinePaths(test.op, a, b, NonZero, 0.01)
+
+		if area := pathArea(p); !nearlyEqual(area, test.area) {
+			t.Errorf("%s: invalid area: %g", test.op, area)
+		}
+
+		for _, rule := range []FillRule{NonZero, EvenOdd} {
+			for _, pt := range test.in {
+				if !p.Contains(pt, rule) {
+					t.Errorf("%s: %s: result does not contain %s", test.op, rule, pt)
+				}
+			}
+
+			for _, pt := range test.out {
+				if p.Contains(pt, rule) {
+					t.Errorf("%s: %s: result contains %s", test.op, rule, pt)
+				}
+			}
+		}
+	}
+}
+
+func TestCombinePathsIntersectionIsRect(t *testing.T) {
+	p := CombinePaths(Intersection, Rect{0, 0, 2, 2}, Rect{1, 1, 2, 2}, NonZero, 0.01)
+
+	if n := len(p.Elements); n != 5 {
+		t.Error("invalid number of elements in the intersection of two rectangles:", p)
+	}
+
+	if b := p.Bounds(); !rectsNearlyEqual(b, Rect{1, 1, 1, 1}) {
+		t.Error("invalid bounds of the intersection of two rectangles:", b)
+	}
+}
+
+func TestCombinePathsSharedEdges(t *testing.T) {
+	p := CombinePaths(Union, Rect{0, 0, 1, 1}, Rect{1, 0, 1, 1}, NonZero, 0.01)
+
+	if n := len(p.Elements); n != 5 {
+		t.Error("union of adjacent rectangles should be a single rectangle:", p)
+	}
+
+	if area := pathArea(p); !nearlyEqual(area, 2) {
+		t.Error("invalid area of the union of adjacent rectangles:", area)
+	}
+
+	if p := CombinePaths(Xor, Rect{0, 0, 1, 1}, Rect{0, 0, 1, 1}, NonZero, 0.01); !p.Empty() {
+		t.Error("xor of identical rectangles should be empty:", p)
+	}
+}
+
+func TestCombinePathsDisjoint(t *testing.T) {
+	p := CombinePaths(Union, Rect{0, 0, 1, 1}, Rect{2, 2, 1, 1}, NonZero, 0.01)
+
+	if n := countMoveTo(p); n != 2 {
+		t.Error("union of disjoint rectangles should have two sub-paths:", p)
+	}
+
+	if p := CombinePaths(Intersection, Rect{0, 0, 1, 1}, Rect{2, 2, 1, 1}, NonZero, 0.01); !p.Empty() {
+		t.Error("intersection of disjoint rectangles should be empty:", p)
+	}
+}
+
+func TestCombinePathsHole(t *testing.T) {
+	p := CombinePaths(Difference, Rect{0, 0, 4, 4}, Rect{1, 1, 2, 2}, NonZero, 0.01)
+
+	// The outer contour and the hole have opposite orientations, so the
+	// shoelace sum is the area of the result.
+	if area := pathArea(p); !nearlyEqual(area, 12) {
+		t.Error("invalid area of a rectangle with a hole:", area)
+	}
+
+	for _, rule := range []FillRule{NonZero, EvenOdd} {
+		if p.Contains(Point{2, 2}, rule) {
+			t.Errorf("%s: hole is filled", rule)
+		}
+
+		if !p.Contains(Point{0.5, 2}, rule) {
+			t.Errorf("%s: rectangle is not filled", rule)
+		}
+	}
+}
+
+func TestCombinePathsFillRule(t *testing.T) {
+	// Nested squares drawn in the same direction, the inner square is a hole
+	// with the even-odd rule only.
+	a := Path{}
+	a = AppendRect(a, Rect{0, 0, 4, 4})
+	a = AppendRect(a, Rect{1, 1, 2, 2})
+	b := Rect{0, 0, 4, 4}
+
+	if p := CombinePaths(Intersection, a, b, NonZero, 0.01); !nearlyEqual(pathArea(p), 16) {
+		t.Error("invalid area of the intersection with the non-zero rule:", pathArea(p))
+	}
+
+	if p := CombinePaths(Intersection, a, b, EvenOdd, 0.01); !nearlyEqual(pathArea(p), 12) {
+		t.Error("invalid area of the intersection with the even-odd rule:", pathArea(p))
+	}
+}
+
+func TestCombinePathsCurves(t *testing.T) {
+	// Intersection of a circle of radius 1 centered at the origin with the
+	// positive quadrant gives a quarter disc.
+	const k = 0.5522847498
+	c := Path{}
+	c.MoveTo(Point{1, 0})
+	c.CubicCurveTo(Point{1, k}, Point{k, 1}, Point{0, 1})
+	c.CubicCurveTo(Point{-k, 1}, Point{-1, k}, Point{-1, 0})
+	c.CubicCurveTo(Point{-1, -k}, Point{-k, -1}, Point{0, -1})
+	c.CubicCurveTo(Point{k, -1}, Point{1, -k}, Point{1, 0})
+	c.Close()
+
+	p := CombinePaths(Intersection, c, Rect{0, 0, 2, 2}, NonZero, 0.001)
+
+	if area := pathArea(p); !nearlyEqualTolerance(area, math.Pi/4, 1e-2) {
+		t.Error("invalid area of the quarter disc:", area)
+	}
+
+	if b := p.Bounds(); !rectsNearlyEqualTolerance(b, Rect{0, 0, 1, 1}, 1e-3) {
+		t.Error("invalid bounds of the quarter disc:", b)
+	}
+}
+
+func rectsNearlyEqualTolerance(r1 Rect, r2 Rect, tolerance float64) bool {
+	return nearlyEqualTolerance(r1.X, r2.X, tolerance) &&
+		nearlyEqualTolerance(r1.Y, r2.Y, tolerance) &&
+		nearlyEqualTolerance(r1.W, r2.W, tolerance) &&
+		nearlyEqualTolerance(r1.H, r2.H, tolerance)
+}
+
+func TestCombinePathsDefaultTolerance(t *testing.T) {
+	// Curves used to be subdivided to the maximum depth when the tolerance
+	// was zero, which made combining them take an unbounded amount of time.
+	const k = 0.5522847498
+	c := Path{}
+	c.MoveTo(Point{1, 0})
+	c.CubicCurveTo(Point{1, k}, Point{k, 1}, Point{0, 1})
+	c.CubicCurveTo(Point{-k, 1}, Point{-1, k}, Point{-1, 0})
+	c.CubicCurveTo(Point{-1, -k}, Point{-k, -1}, Point{0, -1})
+	c.CubicCurveTo(Point{k, -1}, Point{1, -k}, Point{1, 0})
+	c.Close()
+
+	if area := pathArea(CombinePaths(Union, c, c, NonZero, 0)); !nearlyEqualTolerance(area, math.Pi, 1e-2) {
+		t.Error("invalid area of the union of a circle with itself:", area)
+	}
+
+	if p := CombinePaths(Union, Rect{1, 1, 0, 0}, Rect{1, 1, 0, 0}, NonZero, -1); len(p.Elements) != 0 {
+		t.Error("union of empty rectangles is not empty:", p)
+	}
+}
@@ -0,0 +1,137 @@
+package geom
+
+// BoxArea is an enumeration of the nested areas of a box, from the innermost
+// to the outermost.
+type BoxArea int
+
+const (
+	// ContentBox is the area where the content of a box is drawn.
+	ContentBox BoxArea = iota
+
+	// PaddingBox is the area made of the content and the padding around it.
+	PaddingBox
+
+	// BorderBox is the area made of the padding box and the border around it.
+	BorderBox
+
+	// MarginBox is the area made of the border box and the margin around it,
+	// it is the area that a box takes in the layout of its parent.
+	MarginBox
+)
+
+// The String method returns a human-readable representation of the box area.
+func (a BoxArea) String() string {
+	switch a {
+	case ContentBox:
+		return "content-box"
+	case PaddingBox:
+		return "padding-box"
+	case BorderBox:
+		return "border-box"
+	case MarginBox:
+		return "margin-box"
+	default:
+		return "unknown"
+	}
+}
+
+// The Box type represents the CSS box model, where the content of a box is
+// surrounded by padding, a border and a margin.
+type Box struct {
+	Margin  Margin
+	Border  Margin
+	Padding Margin
+
+	// The size of the content area of the box.
+	Content Size
+}
+
+// Insets returns the margin between the content area of the box and the area
+// given as argument.
+func (b Box) Insets(area BoxArea) Margin {
+	return b.layers(ContentBox, area)
+}
+
+// Size returns the size of the area of the box given as argument.
+func (b Box) Size(area BoxArea) Size {
+	return b.ConvertSize(b.Content, ContentBox, area)
+}
+
+// Rect returns the rectangle of the area of the box given as argument, when the
+// top-left corner of its margin box is at the origin point.
+func (b Box) Rect(area BoxArea, origin Point) Rect {
+	r := MakeRect(origin, b.Size(MarginBox))
+	return b.ConvertRect(r, MarginBox, area)
+}
+
+// ConvertRect takes the rectangle of the area of the box given as from, and
+// returns the rectangle of the area given as to.
+//
+// The rectangle is grown when converting to an outer area, and shrunk when
+// converting to an inner area, like the GrowRect and ShrinkRect methods of
+// the Margin type do.
+func (b Box) ConvertRect(r Rect, from BoxArea, to BoxArea) Rect {
+	if to > from {
+		return b.layers(from, to).GrowRect(r)
+	}
+	return b.layers(to, from).ShrinkRect(r)
+}
+
+// ConvertSize takes the size of the area of the box given as from, and returns
+// the size of the area given as to. Converting between ContentBox and BorderBox
+// is the equivalent of switching between the two modes of the CSS box-sizing
+// property.
+//
+// Sizes are never negative, when the layers of the box are larger than the size
+// being shrunk, the resulting dimensions are zero.
+func (b Box) ConvertSize(s Size, from BoxArea, to BoxArea) Size {
+	if to > from {
+		m := b.layers(from, to)
+		return Size{
+			W: s.W + m.Width(),
+			H: s.H + m.Height(),
+		}
+	}
+
+	m := b.layers(to, from)
+	s = Size{
+		W: s.W - m.Width(),
+		H: s.H - m.Height(),
+	}
+
+	if s.W < 0 {
+		s.W = 0
+	}
+
+	if s.H < 0 {
+		s.H = 0
+	}
+
+	return s
+}
+
+// WithSize returns a copy of the box where the content size is computed so the
+// area given as argument has the size s.
+func (b Box) WithSize(s Size, area BoxArea) Box {
+	b.Content = b.ConvertSize(s, area, ContentBox)
+	return b
+}
+
+// layers returns the sum of the layers of the box between the inner and outer
+// areas.
+func (b Box) layers(inner BoxArea, outer BoxArea) Margin {
+	m := Margin{}
+
+	for a := inner; a < outer; a++ {
+		switch a {
+		case ContentBox:
+			m = m.Add(b.Padding)
+		case PaddingBox:
+			m = m.Add(b.Border)
+		case BorderBox:
+			m = m.Add(b.Margin)
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,138 @@
+package geom
+
+import "testing"
+
+var testBox = Box{
+	Margin:  Margin{Top: 1, Bottom: 2, Left: 3, Right: 4},
+	Border:  MakeMargin(1),
+	Padding: Margin{Top: 2, Bottom: 2, Left: 5, Right: 5},
+	Content: Size{100, 50},
+}
+
+func TestBoxAreaString(t *testing.T) {
+	tests := []struct {
+		area BoxArea
+		str  string
+	}{
+		{ContentBox, "content-box"},
+		{PaddingBox, "padding-box"},
+		{BorderBox, "border-box"},
+		{MarginBox, "margin-box"},
+		{BoxArea(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		if s := test.area.String(); s != test.str {
+			t.Errorf("%d: %s != %s", test.area, s, test.str)
+		}
+	}
+}
+
+func TestBoxInsets(t *testing.T) {
+	tests := []struct {
+		area   BoxArea
+		insets Margin
+	}{
+		{ContentBox, Margin{}},
+		{PaddingBox, Margin{Top: 2, Bottom: 2, Left: 5, Right: 5}},
+		{BorderBox, Margin{Top: 3, Bottom: 3, Left: 6, Right: 6}},
+		{MarginBox, Margin{Top: 4, Bottom: 5, Left: 9, Right: 10}},
+	}
+
+	for _, test := range tests {
+		if m := testBox.Insets(test.area); m != test.insets {
+			t.Errorf("%s: %s != %s", test.area, m, test.insets)
+		}
+	}
+}
+
+func TestBoxSize(t *testing.T) {
+	tests := []struct {
+		area BoxArea
+		size Size
+	}{
+		{ContentBox, Size{100, 50}},
+		{PaddingBox, Size{110, 54}},
+		{BorderBox, Size{112, 56}},
+		{MarginBox, Size{119, 59}},
+	}
+
+	for _, test := range tests {
+		if s := testBox.Size(test.area); s != test.size {
+			t.Errorf("%s: %s != %s", test.area, s, test.size)
+		}
+	}
+}
+
+func TestBoxRect(t *testing.T) {
+	tests := []struct {
+		area BoxArea
+		rect Rect
+	}{
+		{MarginBox, Rect{10, 20, 119, 59}},
+		{BorderBox, Rect{13, 21, 112, 56}},
+		{PaddingBox, Rect{14, 22, 110, 54}},
+		{ContentBox, Rect{19, 24, 100, 50}},
+	}
+
+	for _, test := range tests {
+		if r := testBox.Rect(test.area, Point{10, 20}); r != test.rect {
+			t.Errorf("%s: %s != %s", test.area, r, test.rect)
+		}
+	}
+}
+
+func TestBoxConvertRect(t *testing.T) {
+	areas := []BoxArea{ContentBox, PaddingBox, BorderBox, MarginBox}
+	origin := Point{10, 20}
+
+	// Converting the rectangle of any area to any other area must give the
+	// same result as computing it directly.
+	for _, from := range areas {
+		for _, to := range areas {
+			r := testBox.ConvertRect(testBox.Rect(from, origin), from, to)
+
+			if r != testBox.Rect(to, origin) {
+				t.Errorf("%s -> %s: %s != %s", from, to, r, testBox.Rect(to, origin))
+			}
+		}
+	}
+
+	// Shrinking a rectangle smaller than the layers collapses it.
+	if r := testBox.ConvertRect(Rect{0, 0, 10, 4}, BorderBox, ContentBox); r != (Rect{5, 2, 0, 0}) {
+		t.Error("invalid collapsed rectangle:", r)
+	}
+}
+
+func TestBoxConvertSize(t *testing.T) {
+	tests := []struct {
+		size Size
+		from BoxArea
+		to   BoxArea
+		out  Size
+	}{
+		{Size{100, 50}, ContentBox, BorderBox, Size{112, 56}},
+		{Size{112, 56}, BorderBox, ContentBox, Size{100, 50}},
+		{Size{112, 56}, BorderBox, BorderBox, Size{112, 56}},
+		{Size{119, 59}, MarginBox, PaddingBox, Size{110, 54}},
+		{Size{10, 10}, BorderBox, ContentBox, Size{0, 4}},
+	}
+
+	for _, test := range tests {
+		if s := testBox.ConvertSize(test.size, test.from, test.to); s != test.out {
+			t.Errorf("%s (%s -> %s): %s != %s", test.size, test.from, test.to, s, test.out)
+		}
+	}
+}
+
+func TestBoxWithSize(t *testing.T) {
+	b := testBox.WithSize(Size{200, 100}, BorderBox)
+
+	if b.Content != (Size{188, 94}) {
+		t.Error("invalid content size:", b.Content)
+	}
+
+	if s := b.Size(BorderBox); s != (Size{200, 100}) {
+		t.Error("invalid border box size:", s)
+	}
+}
@@ -0,0 +1,56 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+)
+
+// The Circle type represents a circle made of the coordinates of its center and
+// its radius.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Bounds returns the smallest rectangle that contains the circle.
+func (c Circle) Bounds() Rect {
+	return c.Ellipse().Bounds()
+}
+
+// Ellipse converts the circle to an equivalent Ellipse value.
+func (c Circle) Ellipse() Ellipse {
+	r := math.Abs(c.Radius)
+	return Ellipse{
+		Center: c.Center,
+		Radii:  Size{W: r, H: r},
+	}
+}
+
+// ContainsPoint checks whether the point passed as argument is contained in the
+// circle, returning true when that's the case, false otherwise.
+func (c Circle) ContainsPoint(p Point) bool {
+	return c.Center.Distance(p) <= math.Abs(c.Radius)
+}
+
+// The String method returns a human-readable representation of the circle.
+func (c Circle) String() string {
+	return fmt.Sprintf("circle { center = %s, radius = %.6g }", c.Center, c.Radius)
+}
+
+// Path satisfies the Shape interface, allowing Circle values to be used with
+// programs that manipulate shapes.
+func (c Circle) Path() Path {
+	return AppendCircle(MakePath(6), c)
+}
+
+// AppendCircle efficiently append a circle to a Path and returns the modified
+// value.
+//
+// Calling this function is equivalent to calling:
+//
+//	path = AppendPath(path, circle.Path())
+//
+// but the implementation is optimized to avoid unnecessary memory allocations.
+func AppendCircle(path Path, c Circle) Path {
+	return AppendEllipse(path, c.Ellipse())
+}
@@ -0,0 +1,59 @@
+package geom
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCircleEllipse(t *testing.T) {
+	if e := (Circle{Point{1, 2}, -3}).Ellipse(); e != (Ellipse{Point{1, 2}, Size{3, 3}}) {
+		t.Error("invalid ellipse:", e)
+	}
+}
+
+func TestCircleBounds(t *testing.T) {
+	if b := (Circle{Point{1, 2}, 3}).Bounds(); b != (Rect{-2, -1, 6, 6}) {
+		t.Error("invalid circle bounds:", b)
+	}
+}
+
+func TestCircleContainsPoint(t *testing.T) {
+	c := Circle{Point{1, 1}, 2}
+
+	if !c.ContainsPoint(Point{2, 2}) {
+		t.Error("the circle should contain (2, 2)")
+	}
+
+	if c.ContainsPoint(Point{3, 3}) {
+		t.Error("the circle should not contain (3, 3)")
+	}
+}
+
+func TestCircleString(t *testing.T) {
+	if s := (Circle{Point{1, 2}, 3}).String(); s != "circle { center = (1, 2), radius = 3 }" {
+		t.Error(s)
+	}
+}
+
+func TestCirclePath(t *testing.T) {
+	c := Circle{Point{1, 2}, 3}
+	p := c.Path()
+
+	if !reflect.DeepEqual(p, c.Ellipse().Path()) {
+		t.Error("the circle path doesn't match the ellipse path:", p)
+	}
+
+	if l := p.Length(); !nearlyEqualTolerance(l, 6*math.Pi, 1e-2) {
+		t.Errorf("invalid circle perimeter: %g", l)
+	}
+}
+
+func TestAppendCircle(t *testing.T) {
+	c := Circle{Point{1, 2}, 3}
+	r := Rect{0, 0, 1, 1}
+
+	if p := AppendCircle(r.Path(), c); !reflect.DeepEqual(p, AppendPath(r.Path(), c.Path())) {
+		t.Error("invalid path:", p)
+	}
+}
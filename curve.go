@@ -0,0 +1,275 @@
+package geom
+
+import "math"
+
+// curve is a generic representation of the segments drawn by path elements,
+// the degree is 1 for lines, 2 for quadratic curves and 3 for cubic curves, and
+// only the first degree+1 points are meaningful.
+type curve struct {
+	degree int
+	p      [4]Point
+}
+
+// contour is the list of curves drawn by a sub-path, when implicit is true the
+// last curve is the line segment closing the sub-path.
+type contour struct {
+	curves   []curve
+	closed   bool
+	implicit bool
+}
+
+// contours splits the path into the list of curves drawn by each of its
+// sub-paths. Closed sub-paths get an extra line segment when their last point
+// is not equal to their first point.
+func contours(p Path) []contour {
+	var list []contour
+	var c contour
+	var start Point
+	var last Point
+	var open bool
+
+	flush := func(closed bool) {
+		if open {
+			c.closed = closed
+			list = append(list, c)
+		}
+		c, open = contour{}, false
+	}
+
+	for _, e := range p.Elements {
+		switch e.Type {
+		case MoveTo:
+			flush(false)
+			start, last, open = e.Points[0], e.Points[0], true
+
+		case LineTo:
+			open = true
+			c.curves = append(c.curves, curve{degree: 1, p: [4]Point{last, e.Points[0]}})
+			last = e.Points[0]
+
+		case QuadCurveTo:
+			open = true
+			c.curves = append(c.curves, curve{degree: 2, p: [4]Point{last, e.Points[0], e.Points[1]}})
+			last = e.Points[1]
+
+		case CubicCurveTo:
+			open = true
+			c.curves = append(c.curves, curve{degree: 3, p: [4]Point{last, e.Points[0], e.Points[1], e.Points[2]}})
+			last = e.Points[2]
+
+		case ClosePath:
+			if open {
+				if last != start {
+					c.curves = append(c.curves, curve{degree: 1, p: [4]Point{last, start}})
+					c.implicit = true
+				}
+				flush(true)
+			}
+			last = start
+		}
+	}
+
+	flush(false)
+	return list
+}
+
+func (c curve) start() Point {
+	return c.p[0]
+}
+
+func (c curve) end() Point {
+	return c.p[c.degree]
+}
+
+// point returns the point of the curve at parameter t.
+func (c curve) point(t float64) Point {
+	switch c.degree {
+	case 1:
+		return c.p[0].Lerp(c.p[1], t)
+	case 2:
+		return quadPoint(c.p[0], c.p[1], c.p[2], t)
+	default:
+		return cubicPoint(c.p[0], c.p[1], c.p[2], c.p[3], t)
+	}
+}
+
+// derivative returns the derivative of the curve at parameter t.
+func (c curve) derivative(t float64) Point {
+	switch c.degree {
+	case 1:
+		return c.p[1].Sub(c.p[0])
+	case 2:
+		return c.p[1].Sub(c.p[0]).Lerp(c.p[2].Sub(c.p[1]), t).Scale(2)
+	default:
+		return cubicDerivative(c.p, t)
+	}
+}
+
+// tangent returns the direction of the curve at parameter t, falling back to
+// the direction between the control points when the derivative is zero.
+func (c curve) tangent(t float64) Point {
+	if d := c.derivative(t); !d.Zero() {
+		return d.Normalize()
+	}
+
+	if t < 0.5 {
+		for _, p := range c.p[1 : c.degree+1] {
+			if p != c.p[0] {
+				return p.Sub(c.p[0]).Normalize()
+			}
+		}
+	} else {
+		for i := c.degree - 1; i >= 0; i-- {
+			if p := c.p[i]; p != c.end() {
+				return c.end().Sub(p).Normalize()
+			}
+		}
+	}
+
+	return Point{}
+}
+
+// split splits the curve at parameter t and returns the two resulting curves.
+func (c curve) split(t float64) (curve, curve) {
+	a, b := c, c
+
+	switch c.degree {
+	case 1:
+		m := c.p[0].Lerp(c.p[1], t)
+		a.p[1], b.p[0] = m, m
+
+	case 2:
+		q1, q2 := splitQuad([3]Point{c.p[0], c.p[1], c.p[2]}, t)
+		copy(a.p[:], q1[:])
+		copy(b.p[:], q2[:])
+
+	default:
+		a.p, b.p = splitCubic(c.p, t)
+	}
+
+	return a, b
+}
+
+// sub returns the piece of the curve between the parameters t0 and t1.
+func (c curve) sub(t0 float64, t1 float64) curve {
+	if t1 < 1 {
+		c, _ = c.split(t1)
+	}
+
+	if t0 > 0 {
+		_, c = c.split(t0 / t1)
+	}
+
+	return c
+}
+
+// appendTo appends the curve to the path as a path element, the current point
+// of the path is expected to be the start of the curve.
+func (c curve) appendTo(p *Path) {
+	switch c.degree {
+	case 1:
+		p.LineTo(c.p[1])
+	case 2:
+		p.QuadCurveTo(c.p[1], c.p[2])
+	default:
+		p.CubicCurveTo(c.p[1], c.p[2], c.p[3])
+	}
+}
+
+// length returns the arc length of the curve.
+func (c curve) length() float64 {
+	return c.lengthAt(1)
+}
+
+// lengthAt returns the arc length of the curve between parameters 0 and t.
+func (c curve) lengthAt(t float64) float64 {
+	if c.degree == 1 {
+		return c.p[0].Distance(c.p[1]) * t
+	}
+	return c.integrate(0, t, c.gauss(0, t), 0)
+}
+
+// paramAt returns the parameter of the curve where the arc length from the
+// start of the curve is equal to s.
+func (c curve) paramAt(s float64) float64 {
+	l := c.length()
+
+	switch {
+	case s <= 0:
+		return 0
+	case s >= l:
+		return 1
+	case c.degree == 1:
+		return s / l
+	}
+
+	// Newton's method, falling back to bisection when an iteration gets out
+	// of the interval known to contain the solution.
+	lo, hi := 0.0, 1.0
+	t := s / l
+
+	for i := 0; i < 32; i++ {
+		f := c.lengthAt(t) - s
+
+		if math.Abs(f) <= 1e-12*l {
+			break
+		}
+
+		if f < 0 {
+			lo = t
+		} else {
+			hi = t
+		}
+
+		d := c.derivative(t).Length()
+		n := t - f/d
+
+		if d == 0 || n <= lo || n >= hi {
+			n = (lo + hi) / 2
+		}
+
+		t = n
+	}
+
+	return t
+}
+
+// integrate computes the arc length of the curve between t0 and t1 using
+// adaptive Gauss-Legendre quadrature, where g is the estimate of the length
+// over the whole interval.
+func (c curve) integrate(t0 float64, t1 float64, g float64, depth int) float64 {
+	m := (t0 + t1) / 2
+	g0 := c.gauss(t0, m)
+	g1 := c.gauss(m, t1)
+
+	if depth >= maxSubdivisions || math.Abs(g0+g1-g) <= 1e-10*math.Max(1, g) {
+		return g0 + g1
+	}
+
+	return c.integrate(t0, m, g0, depth+1) + c.integrate(m, t1, g1, depth+1)
+}
+
+var gaussLegendre = [...]struct{ x, w float64 }{
+	{-0.9602898564975363, 0.1012285362903763},
+	{-0.7966664774136267, 0.2223810344533745},
+	{-0.5255324099163290, 0.3137066458778873},
+	{-0.1834346424956498, 0.3626837833783620},
+	{0.1834346424956498, 0.3626837833783620},
+	{0.5255324099163290, 0.3137066458778873},
+	{0.7966664774136267, 0.2223810344533745},
+	{0.9602898564975363, 0.1012285362903763},
+}
+
+// gauss estimates the arc length of the curve between t0 and t1 using an eight
+// point Gauss-Legendre quadrature.
+func (c curve) gauss(t0 float64, t1 float64) float64 {
+	h := (t1 - t0) / 2
+	m := (t1 + t0) / 2
+	s := 0.0
+
+	for _, g := range gaussLegendre {
+		s += g.w * c.derivative(m+h*g.x).Length()
+	}
+
+	return s * h
+}
@@ -0,0 +1,75 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestContours(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.LineTo(Point{1, 0})
+	p.QuadCurveTo(Point{1, 1}, Point{0, 1})
+	p.Close()
+	p.MoveTo(Point{2, 2})
+	p.CubicCurveTo(Point{3, 2}, Point{3, 3}, Point{2, 3})
+
+	list := contours(p)
+
+	if len(list) != 2 {
+		t.Error("invalid number of contours:", len(list))
+		return
+	}
+
+	if c := list[0]; !c.closed || len(c.curves) != 3 || c.curves[2].end() != (Point{0, 0}) {
+		t.Errorf("invalid first contour: %+v", c)
+	}
+
+	if c := list[1]; c.closed || len(c.curves) != 1 || c.curves[0].degree != 3 {
+		t.Errorf("invalid second contour: %+v", c)
+	}
+}
+
+func TestCurveLength(t *testing.T) {
+	const k = 0.5522847498 * 10
+
+	tests := []struct {
+		c curve
+		l float64
+	}{
+		{curve{degree: 1, p: [4]Point{{0, 0}, {3, 4}}}, 5},
+		{curve{degree: 2, p: [4]Point{{0, 0}, {1, 0}, {2, 0}}}, 2},
+		{curve{degree: 3, p: [4]Point{{0, 0}, {1, 0}, {2, 0}, {3, 0}}}, 3},
+		{curve{degree: 3, p: [4]Point{{10, 0}, {10, k}, {k, 10}, {0, 10}}}, 5 * math.Pi},
+	}
+
+	for _, test := range tests {
+		if l := test.c.length(); !nearlyEqualTolerance(l, test.l, 1e-2) {
+			t.Errorf("invalid length of curve %+v: %g", test.c, l)
+		}
+	}
+}
+
+func TestCurveParamAt(t *testing.T) {
+	c := curve{degree: 3, p: [4]Point{{0, 0}, {0, 1}, {3, 1}, {3, 0}}}
+	l := c.length()
+
+	for _, s := range []float64{0, 0.1, 0.5, 1, 2, l} {
+		if x := c.lengthAt(c.paramAt(s)); !nearlyEqualTolerance(x, s, 1e-9) {
+			t.Errorf("invalid parameter at length %g: %g", s, x)
+		}
+	}
+}
+
+func TestCurveSub(t *testing.T) {
+	c := curve{degree: 2, p: [4]Point{{0, 0}, {1, 2}, {2, 0}}}
+	s := c.sub(0.25, 0.75)
+
+	if !pointsNearlyEqual(s.start(), c.point(0.25)) || !pointsNearlyEqual(s.end(), c.point(0.75)) {
+		t.Errorf("invalid sub-curve: %+v", s)
+	}
+
+	if !pointsNearlyEqual(s.point(0.5), c.point(0.5)) {
+		t.Errorf("sub-curve does not follow the original curve: %+v", s)
+	}
+}
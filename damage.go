@@ -0,0 +1,226 @@
+package geom
+
+import "sort"
+
+// The DamagePolicy interface represents the strategies used by DamageTracker
+// values to merge rectangles into a list of damaged areas.
+type DamagePolicy interface {
+	// Merge adds a rectangle to a list of damaged areas and returns the
+	// potentially modified list. Implementations are free to reuse the
+	// backing array of the list.
+	Merge(list []Rect, rect Rect) []Rect
+}
+
+// DamagePolicyFunc is an adapter to allow the use of ordinary functions like
+// MergeRect as damage policies.
+type DamagePolicyFunc func(list []Rect, rect Rect) []Rect
+
+// Merge satisfies the DamagePolicy interface by calling f.
+func (f DamagePolicyFunc) Merge(list []Rect, rect Rect) []Rect {
+	return f(list, rect)
+}
+
+// DefaultDamagePolicy is the policy used by DamageTracker values that have no
+// policy configured, it uses MergeRect to merge rectangles.
+var DefaultDamagePolicy DamagePolicy = DamagePolicyFunc(MergeRect)
+
+// BoundingBoxPolicy is a damage policy which keeps a single rectangle that
+// contains every damaged area.
+//
+// This is the cheapest policy to compute but it may cause large areas to be
+// redrawn when distant rectangles are damaged.
+type BoundingBoxPolicy struct{}
+
+// Merge satisfies the DamagePolicy interface.
+func (BoundingBoxPolicy) Merge(list []Rect, rect Rect) []Rect {
+	if len(list) == 0 {
+		return append(list, rect)
+	}
+
+	bounds := rect
+
+	for _, r := range list {
+		bounds = bounds.Merge(r)
+	}
+
+	return append(list[:0], bounds)
+}
+
+// AreaCostPolicy is a damage policy which only merges rectangles when the area
+// added by the merge is small compared to the area of the rectangles.
+//
+// When a rectangle is added, the policy looks for the merge that wastes the
+// least area, and accepts it only when the wasted area (the area of the merged
+// rectangle which wasn't covered by either of the rectangles) is lower than or
+// equal to the threshold multiplied by the covered area. Rectangles are kept
+// sorted by area in descending order, which makes finding rectangles that
+// contain the one being added faster.
+type AreaCostPolicy struct {
+	// The ratio of wasted area allowed when merging rectangles, zero only
+	// merges rectangles when no area is wasted.
+	Threshold float64
+}
+
+// Merge satisfies the DamagePolicy interface.
+func (p AreaCostPolicy) Merge(list []Rect, rect Rect) []Rect {
+	for {
+		// Largest rectangles are first, the search can stop as soon as the
+		// rectangles become smaller than the one being added.
+		for _, r := range list {
+			if r.Area() < rect.Area() {
+				break
+			}
+			if r.ContainsRect(rect) {
+				return list
+			}
+		}
+
+		// Rectangles contained in the one being added are removed, the list
+		// is filtered in place to avoid allocating memory.
+		n := 0
+
+		for _, r := range list {
+			if !rect.ContainsRect(r) {
+				list[n] = r
+				n++
+			}
+		}
+
+		list = list[:n]
+		best := -1
+		bestWaste := 0.0
+
+		for i, r := range list {
+			waste, covered := mergeCost(r, rect)
+
+			if waste <= p.Threshold*covered && (best < 0 || waste < bestWaste) {
+				best, bestWaste = i, waste
+			}
+		}
+
+		if best < 0 {
+			return insertRectByArea(list, rect)
+		}
+
+		// The merged rectangle may now be cheap to merge with other ones, so
+		// it is removed from the list and added again.
+		rect = list[best].Merge(rect)
+		list = append(list[:best], list[best+1:]...)
+	}
+}
+
+// MaxRectsPolicy is a damage policy which limits the number of rectangles in
+// the list, merging the pair of rectangles that wastes the least area when the
+// limit is exceeded.
+type MaxRectsPolicy struct {
+	// The maximum number of rectangles in the list, there is no limit when
+	// the value is zero or negative.
+	Max int
+
+	// The policy used to merge rectangles before the limit is applied,
+	// DefaultDamagePolicy is used when it is nil.
+	Policy DamagePolicy
+}
+
+// Merge satisfies the DamagePolicy interface.
+func (p MaxRectsPolicy) Merge(list []Rect, rect Rect) []Rect {
+	policy := p.Policy
+
+	if policy == nil {
+		policy = DefaultDamagePolicy
+	}
+
+	list = policy.Merge(list, rect)
+
+	for p.Max > 0 && len(list) > p.Max {
+		bi, bj := 0, 1
+		bestWaste := -1.0
+
+		for i := range list {
+			for j := i + 1; j < len(list); j++ {
+				if waste, _ := mergeCost(list[i], list[j]); bestWaste < 0 || waste < bestWaste {
+					bi, bj, bestWaste = i, j, waste
+				}
+			}
+		}
+
+		merged := list[bi].Merge(list[bj])
+		list = append(list[:bj], list[bj+1:]...)
+		list = append(list[:bi], list[bi+1:]...)
+		list = policy.Merge(list, merged)
+	}
+
+	return list
+}
+
+// mergeCost returns the area wasted by merging r1 and r2, and the area covered
+// by the two rectangles.
+func mergeCost(r1 Rect, r2 Rect) (waste float64, covered float64) {
+	covered = r1.Area() + r2.Area() - r1.Intersect(r2).Area()
+	waste = r1.Merge(r2).Area() - covered
+	return
+}
+
+func insertRectByArea(list []Rect, rect Rect) []Rect {
+	i := sort.Search(len(list), func(i int) bool { return list[i].Area() < rect.Area() })
+	list = append(list, Rect{})
+	copy(list[i+1:], list[i:])
+	list[i] = rect
+	return list
+}
+
+// A DamageTracker keeps track of the areas that need to be redrawn, typically
+// in a window or a widget, merging them according to a configurable policy.
+//
+// The zero-value is a valid tracker which uses DefaultDamagePolicy.
+type DamageTracker struct {
+	// The policy used to merge damaged rectangles.
+	Policy DamagePolicy
+
+	rects []Rect
+}
+
+// Add marks the rectangle given as argument as damaged. Empty rectangles are
+// ignored.
+func (t *DamageTracker) Add(rect Rect) {
+	if rect = rect.Abs(); rect.Empty() {
+		return
+	}
+
+	policy := t.Policy
+
+	if policy == nil {
+		policy = DefaultDamagePolicy
+	}
+
+	t.rects = policy.Merge(t.rects, rect)
+}
+
+// Rects returns the list of damaged rectangles. The returned slice is owned by
+// the tracker and is only valid until the next call to Add or Reset.
+func (t *DamageTracker) Rects() []Rect {
+	return t.rects
+}
+
+// Area returns the sum of the areas of the damaged rectangles, which is the
+// area that a program would redraw.
+func (t *DamageTracker) Area() float64 {
+	area := 0.0
+
+	for _, r := range t.rects {
+		area += r.Area()
+	}
+
+	return area
+}
+
+// Empty checks whether no area is damaged.
+func (t *DamageTracker) Empty() bool {
+	return len(t.rects) == 0
+}
+
+// Reset clears the list of damaged rectangles, retaining the memory that was
+// allocated for it.
+func (t *DamageTracker) Reset() {
+	t.rects = t.rects[:0]
+}
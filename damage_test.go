@@ -0,0 +1,198 @@
+package geom
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestDamageTrackerDefaultPolicy(t *testing.T) {
+	d := DamageTracker{}
+	d.Add(Rect{0, 0, 2, 2})
+	d.Add(Rect{1, 1, 2, 2})
+	d.Add(Rect{5, 5, 0, 1})
+
+	if rects := d.Rects(); !reflect.DeepEqual(rects, []Rect{{0, 0, 3, 3}}) {
+		t.Error("invalid damaged rectangles:", rects)
+	}
+
+	if a := d.Area(); a != 9 {
+		t.Error("invalid damaged area:", a)
+	}
+
+	d.Reset()
+
+	if !d.Empty() {
+		t.Error("damage tracker is not empty after being reset:", d.Rects())
+	}
+}
+
+func TestBoundingBoxPolicy(t *testing.T) {
+	d := DamageTracker{Policy: BoundingBoxPolicy{}}
+	d.Add(Rect{0, 0, 1, 1})
+	d.Add(Rect{4, 4, 1, 1})
+
+	if rects := d.Rects(); !reflect.DeepEqual(rects, []Rect{{0, 0, 5, 5}}) {
+		t.Error("invalid damaged rectangles:", rects)
+	}
+}
+
+func TestAreaCostPolicy(t *testing.T) {
+	tests := []struct {
+		key       string
+		threshold float64
+		in        []Rect
+		out       []Rect
+	}{
+		{
+			key:       "contained rectangles are ignored",
+			threshold: 0,
+			in:        []Rect{{0, 0, 4, 4}, {1, 1, 1, 1}},
+			out:       []Rect{{0, 0, 4, 4}},
+		},
+		{
+			key:       "containing rectangles replace the ones they contain",
+			threshold: 0,
+			in:        []Rect{{1, 1, 1, 1}, {3, 3, 1, 1}, {0, 0, 5, 5}},
+			out:       []Rect{{0, 0, 5, 5}},
+		},
+		{
+			key:       "aligned adjacent rectangles are merged",
+			threshold: 0,
+			in:        []Rect{{0, 0, 1, 1}, {1, 0, 1, 1}},
+			out:       []Rect{{0, 0, 2, 1}},
+		},
+		{
+			key:       "diagonal rectangles are kept separate",
+			threshold: 0.25,
+			in:        []Rect{{0, 0, 2, 2}, {1, 1, 2, 2}},
+			out:       []Rect{{0, 0, 2, 2}, {1, 1, 2, 2}},
+		},
+		{
+			key:       "diagonal rectangles are merged with a high threshold",
+			threshold: 1,
+			in:        []Rect{{0, 0, 2, 2}, {1, 1, 2, 2}},
+			out:       []Rect{{0, 0, 3, 3}},
+		},
+		{
+			key:       "rectangles are sorted by area",
+			threshold: 0,
+			in:        []Rect{{0, 0, 1, 1}, {5, 5, 3, 3}, {10, 10, 2, 2}},
+			out:       []Rect{{5, 5, 3, 3}, {10, 10, 2, 2}, {0, 0, 1, 1}},
+		},
+		{
+			key:       "merges cascade",
+			threshold: 0,
+			in:        []Rect{{0, 0, 1, 1}, {2, 0, 1, 1}, {1, 0, 1, 1}},
+			out:       []Rect{{0, 0, 3, 1}},
+		},
+	}
+
+	for _, test := range tests {
+		d := DamageTracker{Policy: AreaCostPolicy{Threshold: test.threshold}}
+
+		for _, r := range test.in {
+			d.Add(r)
+		}
+
+		if rects := d.Rects(); !reflect.DeepEqual(rects, test.out) {
+			t.Errorf("AreaCostPolicy: %s: %v", test.key, rects)
+		}
+	}
+}
+
+func TestMaxRectsPolicy(t *testing.T) {
+	d := DamageTracker{Policy: MaxRectsPolicy{Max: 2}}
+	d.Add(Rect{0, 0, 1, 1})
+	d.Add(Rect{10, 0, 1, 1})
+	d.Add(Rect{2, 0, 1, 1})
+
+	if rects := d.Rects(); !reflect.DeepEqual(rects, []Rect{{10, 0, 1, 1}, {0, 0, 3, 1}}) {
+		t.Error("invalid damaged rectangles:", rects)
+	}
+}
+
+func TestDamagePolicyFunc(t *testing.T) {
+	called := false
+	d := DamageTracker{Policy: DamagePolicyFunc(func(list []Rect, rect Rect) []Rect {
+		called = true
+		return append(list, rect)
+	})}
+	d.Add(Rect{0, 0, 1, 1})
+
+	if !called {
+		t.Error("damage policy function was not called")
+	}
+}
+
+// damageWorkload generates a deterministic list of rectangles simulating
+// widgets being damaged in a window, mixing small rectangles clustered in a
+// few areas and a few large ones.
+func damageWorkload() []Rect {
+	rng := rand.New(rand.NewSource(42))
+	rects := make([]Rect, 0, 200)
+
+	for i := 0; i < 200; i++ {
+		cx := float64(rng.Intn(4)) * 400
+		cy := float64(rng.Intn(3)) * 300
+		w := float64(10 + rng.Intn(100))
+		h := float64(10 + rng.Intn(60))
+
+		if i%50 == 0 {
+			w, h = 300, 200
+		}
+
+		rects = append(rects, Rect{
+			X: cx + float64(rng.Intn(300)),
+			Y: cy + float64(rng.Intn(200)),
+			W: w,
+			H: h,
+		})
+	}
+
+	return rects
+}
+
+func benchmarkDamagePolicy(b *testing.B, policy DamagePolicy) {
+	rects := damageWorkload()
+	exact := MakeRegion(rects...).Area()
+	d := DamageTracker{Policy: policy}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		d.Reset()
+
+		for _, r := range rects {
+			d.Add(r)
+		}
+	}
+
+	b.ReportMetric(d.Area(), "area")
+	b.ReportMetric(d.Area()/exact, "overdraw")
+	b.ReportMetric(float64(len(d.Rects())), "rects")
+}
+
+func BenchmarkDamageMergeRect(b *testing.B) {
+	benchmarkDamagePolicy(b, DefaultDamagePolicy)
+}
+
+func BenchmarkDamageBoundingBox(b *testing.B) {
+	benchmarkDamagePolicy(b, BoundingBoxPolicy{})
+}
+
+func BenchmarkDamageAreaCost0(b *testing.B) {
+	benchmarkDamagePolicy(b, AreaCostPolicy{Threshold: 0})
+}
+
+func BenchmarkDamageAreaCost10(b *testing.B) {
+	benchmarkDamagePolicy(b, AreaCostPolicy{Threshold: 0.1})
+}
+
+func BenchmarkDamageAreaCost25(b *testing.B) {
+	benchmarkDamagePolicy(b, AreaCostPolicy{Threshold: 0.25})
+}
+
+func BenchmarkDamageMaxRects16(b *testing.B) {
+	benchmarkDamagePolicy(b, MaxRectsPolicy{Max: 16, Policy: AreaCostPolicy{Threshold: 0.1}})
+}
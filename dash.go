@@ -0,0 +1,170 @@
+package geom
+
+import "math"
+
+// Dash applies a dash pattern to the path and returns a new path made of the
+// "on" segments of the pattern, which is typically stroked afterward.
+//
+// The dashes argument alternates the lengths of "on" and "off" segments, when
+// it has an odd number of values it is repeated to get an even number, like
+// SVG's stroke-dasharray property. The offset argument is the distance into
+// the pattern where each sub-path starts.
+//
+// Curves are split at the exact positions of the dashes, they are not
+// approximated by line segments. When the first and last dash of a closed
+// sub-path are both "on" they are joined into a single dash.
+//
+// If the dash pattern is empty, contains negative values or only zeros, a copy
+// of the path is returned.
+func Dash(path Path, dashes []float64, offset float64) Path {
+	total := 0.0
+
+	for _, d := range dashes {
+		if d < 0 || math.IsNaN(d) || math.IsInf(d, 0) {
+			return path.Copy()
+		}
+		total += d
+	}
+
+	if total == 0 {
+		return path.Copy()
+	}
+
+	if len(dashes)%2 != 0 {
+		dashes = append(dashes[:len(dashes):len(dashes)], dashes...)
+		total *= 2
+	}
+
+	// Find the position in the dash pattern where sub-paths start.
+	offset = math.Mod(offset, total)
+
+	if offset < 0 {
+		offset += total
+	}
+
+	index := 0
+
+	for offset > 0 && offset >= dashes[index] {
+		offset -= dashes[index]
+		index = (index + 1) % len(dashes)
+	}
+
+	out := MakePath(len(path.Elements))
+
+	for _, c := range contours(path) {
+		if len(c.curves) == 0 {
+			continue
+		}
+
+		d := dasher{
+			dashes: dashes,
+			index:  index,
+			rem:    dashes[index] - offset,
+		}
+		out = d.contour(out, c)
+	}
+
+	return out
+}
+
+type dasher struct {
+	dashes []float64
+	index  int
+	rem    float64
+
+	list []Path
+	cur  Path
+}
+
+func (d *dasher) on() bool {
+	return d.index%2 == 0
+}
+
+func (d *dasher) contour(out Path, c contour) Path {
+	startsOn := d.on()
+
+	if startsOn {
+		d.cur.MoveTo(c.curves[0].start())
+	}
+
+	for _, cv := range c.curves {
+		d.curve(cv)
+	}
+
+	endsOn := d.on()
+	d.end()
+
+	// A closed sub-path which is entirely covered by a single dash is kept
+	// as is, with its closing element.
+	if c.closed && startsOn && endsOn && len(d.list) == 1 {
+		curves := c.curves
+
+		if c.implicit {
+			curves = curves[:len(curves)-1]
+		}
+
+		out.MoveTo(curves[0].start())
+
+		for _, cv := range curves {
+			cv.appendTo(&out)
+		}
+
+		out.Close()
+		return out
+	}
+
+	if c.closed && startsOn && endsOn && len(d.list) > 1 {
+		first, last := d.list[0], d.list[len(d.list)-1]
+		last = AppendPath(last, Path{Elements: first.Elements[1:]})
+		d.list = append(d.list[1:len(d.list)-1], last)
+	}
+
+	for _, p := range d.list {
+		out = AppendPath(out, p)
+	}
+
+	return out
+}
+
+func (d *dasher) curve(c curve) {
+	l := c.length()
+	pos := 0.0
+	t0 := 0.0
+
+	for l-pos > d.rem {
+		pos += d.rem
+		t1 := c.paramAt(pos)
+
+		if d.on() {
+			c.sub(t0, t1).appendTo(&d.cur)
+			d.end()
+		}
+
+		d.index = (d.index + 1) % len(d.dashes)
+		d.rem = d.dashes[d.index]
+		t0 = t1
+
+		if d.on() {
+			d.cur.MoveTo(c.point(t1))
+		}
+	}
+
+	if d.on() && t0 < 1 {
+		c.sub(t0, 1).appendTo(&d.cur)
+	}
+
+	d.rem -= l - pos
+}
+
+// end terminates the current dash, dashes of length zero get a line segment of
+// length zero so they can be drawn by caps when the path is stroked.
+func (d *dasher) end() {
+	switch len(d.cur.Elements) {
+	case 0:
+		return
+	case 1:
+		d.cur.LineTo(d.cur.LastPoint())
+	}
+	d.list = append(d.list, d.cur)
+	d.cur = Path{}
+}
@@ -0,0 +1,119 @@
+package geom
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// dashRanges returns the start and end x coordinates of each sub-path of a path
+// made of horizontal lines.
+func dashRanges(p Path) [][2]float64 {
+	var r [][2]float64
+
+	for _, e := range p.Elements {
+		switch e.Type {
+		case MoveTo:
+			r = append(r, [2]float64{e.Points[0].X, e.Points[0].X})
+		case LineTo:
+			r[len(r)-1][1] = e.Points[0].X
+		}
+	}
+
+	return r
+}
+
+func TestDashLine(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.LineTo(Point{10, 0})
+
+	tests := []struct {
+		dashes []float64
+		offset float64
+		ranges [][2]float64
+	}{
+		{[]float64{2, 1}, 0, [][2]float64{{0, 2}, {3, 5}, {6, 8}, {9, 10}}},
+		{[]float64{2, 1}, 1, [][2]float64{{0, 1}, {2, 4}, {5, 7}, {8, 10}}},
+		{[]float64{2, 1}, -1, [][2]float64{{1, 3}, {4, 6}, {7, 9}}},
+		{[]float64{3}, 0, [][2]float64{{0, 3}, {6, 9}}},
+		{[]float64{0, 4}, 0, [][2]float64{{0, 0}, {4, 4}, {8, 8}}},
+	}
+
+	for _, test := range tests {
+		if r := dashRanges(Dash(p, test.dashes, test.offset)); !reflect.DeepEqual(r, test.ranges) {
+			t.Errorf("Dash(%v, %g): %v", test.dashes, test.offset, r)
+		}
+	}
+}
+
+func TestDashInvalidPattern(t *testing.T) {
+	p := Rect{0, 0, 1, 1}.Path()
+
+	for _, dashes := range [][]float64{nil, {0, 0}, {1, -1}} {
+		if d := Dash(p, dashes, 0); !reflect.DeepEqual(d, p) {
+			t.Errorf("Dash(%v) modified the path: %v", dashes, d)
+		}
+	}
+}
+
+func TestDashClosed(t *testing.T) {
+	p := Rect{0, 0, 4, 4}.Path()
+
+	// The pattern ends on an "off" segment, the sub-path is split into four
+	// independent dashes.
+	d := Dash(p, []float64{3, 1}, 0)
+
+	if n := countMoveTo(d); n != 4 {
+		t.Error("invalid number of dashes:", n, d)
+	}
+
+	// The pattern starts and ends with an "on" segment, the first and last
+	// dashes are joined.
+	d = Dash(p, []float64{3, 1}, 2)
+
+	if n := countMoveTo(d); n != 4 {
+		t.Error("invalid number of dashes:", n, d)
+	}
+
+	if pt := d.LastPoint(); pt != (Point{1, 0}) {
+		t.Error("last dash was not joined with the first one:", pt)
+	}
+
+	// A single dash covering the whole sub-path keeps the original path.
+	if d = Dash(p, []float64{20, 1}, 0); !reflect.DeepEqual(d, p) {
+		t.Error("path fully covered by a dash was modified:", d)
+	}
+}
+
+func TestDashCurve(t *testing.T) {
+	// Quarter circle of radius 10, the first dash ends half way through.
+	const k = 0.5522847498 * 10
+	p := Path{}
+	p.MoveTo(Point{10, 0})
+	p.CubicCurveTo(Point{10, k}, Point{k, 10}, Point{0, 10})
+
+	l := curve{degree: 3, p: [4]Point{{10, 0}, {10, k}, {k, 10}, {0, 10}}}.length()
+	d := Dash(p, []float64{l / 2, l}, 0)
+
+	if len(d.Elements) != 2 || d.Elements[1].Type != CubicCurveTo {
+		t.Error("curve was not split into a cubic curve:", d)
+		return
+	}
+
+	if pt := d.LastPoint(); !nearlyEqualTolerance(pt.Angle(), math.Pi/4, 1e-3) {
+		t.Error("dash does not end half way through the curve:", pt)
+	}
+}
+
+func countMoveTo(p Path) int {
+	n := 0
+
+	for _, e := range p.Elements {
+		if e.Type == MoveTo {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,82 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+)
+
+// kappa is the distance of the control points from the end points, relative to
+// the radius, of the cubic Bézier curves used to approximate quarters of circles
+// (4/3 * (sqrt(2) - 1)). The curves go exactly through the middle of the arcs,
+// with a maximum radial error of about 0.027%.
+const kappa = 0.5522847498307936
+
+// The Ellipse type represents an axis-aligned ellipse made of the coordinates
+// of its center and its horizontal and vertical radii.
+type Ellipse struct {
+	Center Point
+	Radii  Size
+}
+
+// MakeEllipse constructs an Ellipse value which fits exactly in the rectangle
+// given as argument.
+func MakeEllipse(r Rect) Ellipse {
+	r = r.Abs()
+	return Ellipse{
+		Center: r.Center(),
+		Radii:  Size{W: r.W / 2, H: r.H / 2},
+	}
+}
+
+// Bounds returns the smallest rectangle that contains the ellipse.
+func (e Ellipse) Bounds() Rect {
+	rx, ry := math.Abs(e.Radii.W), math.Abs(e.Radii.H)
+	return Rect{
+		X: e.Center.X - rx,
+		Y: e.Center.Y - ry,
+		W: 2 * rx,
+		H: 2 * ry,
+	}
+}
+
+// ContainsPoint checks whether the point passed as argument is contained in the
+// ellipse, returning true when that's the case, false otherwise.
+func (e Ellipse) ContainsPoint(p Point) bool {
+	if e.Radii.W == 0 || e.Radii.H == 0 {
+		return false
+	}
+	dx := (p.X - e.Center.X) / e.Radii.W
+	dy := (p.Y - e.Center.Y) / e.Radii.H
+	return (dx*dx + dy*dy) <= 1
+}
+
+// The String method returns a human-readable representation of the ellipse.
+func (e Ellipse) String() string {
+	return fmt.Sprintf("ellipse { center = %s, radii = %s }", e.Center, e.Radii)
+}
+
+// Path satisfies the Shape interface, allowing Ellipse values to be used with
+// programs that manipulate shapes.
+func (e Ellipse) Path() Path {
+	return AppendEllipse(MakePath(6), e)
+}
+
+// AppendEllipse efficiently append an ellipse to a Path and returns the
+// modified value.
+//
+// The ellipse is approximated by four cubic Bézier curves, the sub-path starts
+// at the right-most point of the ellipse and goes in the same direction as the
+// sub-paths of rectangles.
+func AppendEllipse(path Path, e Ellipse) Path {
+	cx, cy := e.Center.X, e.Center.Y
+	rx, ry := math.Abs(e.Radii.W), math.Abs(e.Radii.H)
+	kx, ky := rx*kappa, ry*kappa
+
+	path.MoveTo(Point{cx + rx, cy})
+	path.CubicCurveTo(Point{cx + rx, cy + ky}, Point{cx + kx, cy + ry}, Point{cx, cy + ry})
+	path.CubicCurveTo(Point{cx - kx, cy + ry}, Point{cx - rx, cy + ky}, Point{cx - rx, cy})
+	path.CubicCurveTo(Point{cx - rx, cy - ky}, Point{cx - kx, cy - ry}, Point{cx, cy - ry})
+	path.CubicCurveTo(Point{cx + kx, cy - ry}, Point{cx + rx, cy - ky}, Point{cx + rx, cy})
+	path.Close()
+	return path
+}
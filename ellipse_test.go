@@ -0,0 +1,108 @@
+package geom
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMakeEllipse(t *testing.T) {
+	if e := MakeEllipse(Rect{10, 10, -4, 2}); e != (Ellipse{Point{8, 11}, Size{2, 1}}) {
+		t.Error("invalid ellipse:", e)
+	}
+}
+
+func TestEllipseBounds(t *testing.T) {
+	e := Ellipse{Point{1, 2}, Size{3, -4}}
+
+	if b := e.Bounds(); b != (Rect{-2, -2, 6, 8}) {
+		t.Error("invalid ellipse bounds:", b)
+	}
+
+	p := e.Path()
+
+	if b := p.Bounds(); !rectsNearlyEqual(b, e.Bounds()) {
+		t.Error("invalid ellipse path bounds:", b)
+	}
+}
+
+func TestEllipseContainsPoint(t *testing.T) {
+	e := Ellipse{Point{0, 0}, Size{4, 2}}
+
+	tests := []struct {
+		point    Point
+		contains bool
+	}{
+		{Point{0, 0}, true},
+		{Point{4, 0}, true},
+		{Point{0, -2}, true},
+		{Point{3, 1.5}, false},
+		{Point{0, 2.1}, false},
+	}
+
+	for _, test := range tests {
+		if c := e.ContainsPoint(test.point); c != test.contains {
+			t.Errorf("%s: %v: %t", e, test.point, c)
+		}
+	}
+
+	if (Ellipse{Radii: Size{0, 1}}).ContainsPoint(Point{}) {
+		t.Error("a flat ellipse must not contain any point")
+	}
+}
+
+func TestEllipseString(t *testing.T) {
+	if s := (Ellipse{Point{1, 2}, Size{3, 4}}).String(); s != "ellipse { center = (1, 2), radii = [3, 4] }" {
+		t.Error(s)
+	}
+}
+
+func TestEllipsePath(t *testing.T) {
+	e := Ellipse{Point{10, 20}, Size{8, 4}}
+	p := e.Path()
+
+	if n := len(p.Elements); n != 6 || p.Elements[0].Type != MoveTo || p.Elements[5].Type != ClosePath {
+		t.Fatal("invalid ellipse path:", p)
+	}
+
+	// Every point of the approximation must be very close to the ellipse.
+	for _, c := range contours(p) {
+		for _, cv := range c.curves {
+			for i := 0; i <= 16; i++ {
+				pt := cv.point(float64(i) / 16)
+				dx := (pt.X - e.Center.X) / e.Radii.W
+				dy := (pt.Y - e.Center.Y) / e.Radii.H
+
+				if d := math.Sqrt(dx*dx + dy*dy); math.Abs(d-1) > 3e-4 {
+					t.Errorf("point too far from the ellipse: %v (%g)", pt, d)
+				}
+			}
+		}
+	}
+
+	if a := pathArea(p); !nearlyEqualTolerance(a, math.Pi*8*4, 5e-2) {
+		t.Errorf("invalid ellipse area: %g", a)
+	}
+
+	if !p.Contains(Point{10, 20}, NonZero) {
+		t.Error("the center of the ellipse is not inside its path")
+	}
+}
+
+func TestAppendEllipse(t *testing.T) {
+	e1 := Ellipse{Point{0, 0}, Size{1, 2}}
+	e2 := Ellipse{Point{5, 5}, Size{2, 1}}
+	p := MakePath(12)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		p = AppendEllipse(AppendEllipse(Path{Elements: p.Elements[:0]}, e1), e2)
+	})
+
+	if allocs != 0 {
+		t.Error("appending ellipses to a path with enough capacity allocated memory:", allocs)
+	}
+
+	if r := AppendPath(e1.Path(), e2.Path()); !reflect.DeepEqual(p, r) {
+		t.Errorf("%v != %v", p, r)
+	}
+}
@@ -0,0 +1,191 @@
+package geom
+
+import "sort"
+
+// FillRule is an enumeration of the rules that can be used to determine which
+// areas are inside of a path.
+type FillRule int
+
+const (
+	// NonZero is the fill rule which considers a point to be inside of a path
+	// when the path winds around it a non-zero number of times.
+	NonZero FillRule = iota
+
+	// EvenOdd is the fill rule which considers a point to be inside of a path
+	// when a ray from the point crosses the path an odd number of times.
+	EvenOdd
+)
+
+// The String method returns a human-readable representation of the fill rule.
+func (r FillRule) String() string {
+	switch r {
+	case NonZero:
+		return "nonzero"
+	case EvenOdd:
+		return "evenodd"
+	default:
+		return "unknown"
+	}
+}
+
+// Contains checks whether the point given as argument is inside of the path
+// according to the fill rule, returning true when that's the case, false
+// otherwise.
+//
+// Every sub-path is implicitly closed, and curves are handled exactly by
+// solving for their intersections with a horizontal ray going through the
+// point, not by approximating them with line segments.
+func (p *Path) Contains(pt Point, rule FillRule) bool {
+	w := p.Winding(pt)
+
+	if rule == EvenOdd {
+		return w%2 != 0
+	}
+
+	return w != 0
+}
+
+// Winding computes and returns the winding number of the path around the point
+// given as argument. Every sub-path is implicitly closed.
+func (p *Path) Winding(pt Point) int {
+	var start Point
+	var last Point
+	var w int
+	var ts [6]float64
+
+	for _, e := range p.Elements {
+		switch e.Type {
+		case MoveTo:
+			w += lineWinding(last, start, pt)
+			start, last = e.Points[0], e.Points[0]
+
+		case LineTo:
+			w += lineWinding(last, e.Points[0], pt)
+			last = e.Points[0]
+
+		case QuadCurveTo:
+			c := [3]Point{last, e.Points[0], e.Points[1]}
+			w += curveWinding(quadExtrema(ts[:0], c[0].Y, c[1].Y, c[2].Y), pt, c[:], func(t float64) Point {
+				return quadPoint(c[0], c[1], c[2], t)
+			})
+			last = e.Points[1]
+
+		case CubicCurveTo:
+			c := [4]Point{last, e.Points[0], e.Points[1], e.Points[2]}
+			w += curveWinding(cubicExtrema(ts[:0], c[0].Y, c[1].Y, c[2].Y, c[3].Y), pt, c[:], func(t float64) Point {
+				return cubicPoint(c[0], c[1], c[2], c[3], t)
+			})
+			last = e.Points[2]
+
+		case ClosePath:
+			w += lineWinding(last, start, pt)
+			last = start
+		}
+	}
+
+	return w + lineWinding(last, start, pt)
+}
+
+// lineWinding returns the contribution of the line segment going from a to b to
+// the winding number around pt, counting crossings of a ray going from pt
+// towards positive x.
+func lineWinding(a Point, b Point, pt Point) int {
+	switch {
+	case a.Y <= pt.Y && pt.Y < b.Y:
+		if b.Sub(a).Cross(pt.Sub(a)) > 0 {
+			return 1
+		}
+
+	case b.Y <= pt.Y && pt.Y < a.Y:
+		if b.Sub(a).Cross(pt.Sub(a)) < 0 {
+			return -1
+		}
+	}
+	return 0
+}
+
+// curveWinding returns the contribution of a curve to the winding number around
+// pt. The curve is defined by its control points and eval function, and ts must
+// contain the parameters where the curve has vertical extrema.
+func curveWinding(ts []float64, pt Point, control []Point, eval func(float64) Point) int {
+	minY, maxY := control[0].Y, control[0].Y
+	maxX := control[0].X
+
+	for _, c := range control[1:] {
+		switch {
+		case c.Y < minY:
+			minY = c.Y
+		case c.Y > maxY:
+			maxY = c.Y
+		}
+		if c.X > maxX {
+			maxX = c.X
+		}
+	}
+
+	// Fast path, the curve is entirely above, below or on the left of the
+	// ray so it can't intersect with it.
+	if pt.Y < minY || pt.Y >= maxY || pt.X >= maxX {
+		return 0
+	}
+
+	sort.Float64s(ts)
+	ts = append(ts, 1)
+
+	w := 0
+	t0, p0 := 0.0, control[0]
+
+	// The curve is split in pieces that are monotonic on the y axis, which
+	// means they each intersect with the ray at most once.
+	for _, t1 := range ts {
+		p1 := control[len(control)-1]
+
+		if t1 != 1 {
+			p1 = eval(t1)
+		}
+
+		if crossesY(p0, p1, pt.Y) {
+			if x := solveMonotonicX(eval, t0, t1, p0.Y < p1.Y, pt.Y); x > pt.X {
+				if p0.Y < p1.Y {
+					w++
+				} else {
+					w--
+				}
+			}
+		}
+
+		t0, p0 = t1, p1
+	}
+
+	return w
+}
+
+// crossesY checks whether a monotonic piece going from a to b crosses the
+// horizontal line at y, using the same half-open convention as lineWinding.
+func crossesY(a Point, b Point, y float64) bool {
+	return (a.Y <= y && y < b.Y) || (b.Y <= y && y < a.Y)
+}
+
+// solveMonotonicX uses bisection to find the x coordinate where the piece of
+// curve between t0 and t1, which must be monotonic on the y axis, crosses the
+// horizontal line at y.
+func solveMonotonicX(eval func(float64) Point, t0 float64, t1 float64, up bool, y float64) float64 {
+	var p Point
+
+	for i := 0; i < 64; i++ {
+		t := (t0 + t1) / 2
+		p = eval(t)
+
+		if t == t0 || t == t1 {
+			break
+		}
+
+		if (p.Y < y) == up {
+			t0 = t
+		} else {
+			t1 = t
+		}
+	}
+
+	return p.X
+}
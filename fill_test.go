@@ -0,0 +1,152 @@
+package geom
+
+import "testing"
+
+func TestFillRuleString(t *testing.T) {
+	tests := []struct {
+		rule FillRule
+		str  string
+	}{
+		{NonZero, "nonzero"},
+		{EvenOdd, "evenodd"},
+		{FillRule(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		if s := test.rule.String(); s != test.str {
+			t.Errorf("invalid string representation of fill rule %d: %s", test.rule, s)
+		}
+	}
+}
+
+func TestPathContainsRect(t *testing.T) {
+	p := Rect{0, 0, 2, 2}.Path()
+
+	tests := []struct {
+		pt  Point
+		out bool
+	}{
+		{Point{1, 1}, true},
+		{Point{0.1, 1.9}, true},
+		{Point{-1, 1}, false},
+		{Point{3, 1}, false},
+		{Point{1, -1}, false},
+		{Point{1, 3}, false},
+	}
+
+	for _, test := range tests {
+		for _, rule := range []FillRule{NonZero, EvenOdd} {
+			if p.Contains(test.pt, rule) != test.out {
+				t.Errorf("rectangle path contains %s with rule %s: %t", test.pt, rule, !test.out)
+			}
+		}
+	}
+}
+
+func TestPathContainsFillRules(t *testing.T) {
+	// Two nested squares drawn in the same direction, the inner square is
+	// inside of the path with the non-zero rule but outside with even-odd.
+	p := Path{}
+	p = AppendRect(p, Rect{0, 0, 4, 4})
+	p = AppendRect(p, Rect{1, 1, 2, 2})
+
+	if !p.Contains(Point{2, 2}, NonZero) {
+		t.Error("inner square should be inside of the path with the non-zero rule")
+	}
+
+	if p.Contains(Point{2, 2}, EvenOdd) {
+		t.Error("inner square should be outside of the path with the even-odd rule")
+	}
+
+	if !p.Contains(Point{0.5, 2}, EvenOdd) {
+		t.Error("outer square should be inside of the path with the even-odd rule")
+	}
+
+	if w := p.Winding(Point{2, 2}); w != 2 && w != -2 {
+		t.Error("invalid winding number of nested squares:", w)
+	}
+}
+
+func TestPathContainsCurves(t *testing.T) {
+	// A lens shape made of two quadratic curves, the curves reach y = -1 and
+	// y = 1 at x = 1 while the control points are at y = -2 and y = 2.
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.QuadCurveTo(Point{1, 2}, Point{2, 0})
+	p.QuadCurveTo(Point{1, -2}, Point{0, 0})
+	p.Close()
+
+	tests := []struct {
+		pt  Point
+		out bool
+	}{
+		{Point{1, 0}, true},
+		{Point{1, 0.99}, true},
+		{Point{1, 1.01}, false},
+		{Point{1, -0.99}, true},
+		{Point{1, -1.01}, false},
+		{Point{0.1, 0.1}, true},
+		{Point{0.1, 0.3}, false},
+		{Point{-0.1, 0}, false},
+	}
+
+	for _, test := range tests {
+		if p.Contains(test.pt, NonZero) != test.out {
+			t.Errorf("lens path contains %s: %t", test.pt, !test.out)
+		}
+	}
+}
+
+func TestPathContainsCubicLoop(t *testing.T) {
+	// A circle approximated by cubic curves, which are not monotonic on the
+	// y axis.
+	const k = 0.5522847498
+	p := Path{}
+	p.MoveTo(Point{1, 0})
+	p.CubicCurveTo(Point{1, k}, Point{k, 1}, Point{0, 1})
+	p.CubicCurveTo(Point{-k, 1}, Point{-1, k}, Point{-1, 0})
+	p.CubicCurveTo(Point{-1, -k}, Point{-k, -1}, Point{0, -1})
+	p.CubicCurveTo(Point{k, -1}, Point{1, -k}, Point{1, 0})
+
+	for _, pt := range []Point{{0, 0}, {0.7, 0.7}, {-0.7, 0.7}, {0, 0.99}, {0.99, 0}} {
+		if !p.Contains(pt, EvenOdd) {
+			t.Error("circle path does not contain", pt)
+		}
+	}
+
+	for _, pt := range []Point{{0.72, 0.72}, {-0.72, -0.72}, {0, 1.01}, {1.01, 0}} {
+		if p.Contains(pt, EvenOdd) {
+			t.Error("circle path contains", pt)
+		}
+	}
+}
+
+func TestPathContainsEmpty(t *testing.T) {
+	p := Path{}
+
+	if p.Contains(Point{}, NonZero) {
+		t.Error("empty path contains a point")
+	}
+}
+
+func TestPathContainsLinesAndCurves(t *testing.T) {
+	// A quarter of a disc, made of two lines and a cubic curve.
+	const k = 0.5522847498
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.LineTo(Point{1, 0})
+	p.CubicCurveTo(Point{1, k}, Point{k, 1}, Point{0, 1})
+	p.Close()
+
+	for _, pt := range []Point{{0.1, 0.1}, {0.6, 0.6}, {0.9, 0.1}, {0.1, 0.9}} {
+		if !p.Contains(pt, NonZero) {
+			t.Error("quarter disc does not contain", pt)
+		}
+	}
+
+	for _, pt := range []Point{{-0.1, 0.5}, {0.5, -0.1}, {0.75, 0.75}, {1.1, 0.1}} {
+		if p.Contains(pt, NonZero) {
+			t.Error("quarter disc contains", pt)
+		}
+	}
+}
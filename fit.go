@@ -0,0 +1,129 @@
+package geom
+
+import "math"
+
+// FitMode is an enumeration of the ways content can be scaled to fit in a
+// container, like the object-fit property of CSS.
+type FitMode int
+
+const (
+	// FitContain scales the content to the largest size that fits in the
+	// container while preserving its aspect ratio.
+	FitContain FitMode = iota
+
+	// FitCover scales the content to the smallest size that covers the
+	// container while preserving its aspect ratio, the parts of the content
+	// outside of the container are cropped.
+	FitCover
+
+	// FitFill scales the content to the size of the container, ignoring its
+	// aspect ratio.
+	FitFill
+
+	// FitNone doesn't scale the content, the parts of the content outside of
+	// the container are cropped.
+	FitNone
+
+	// FitScaleDown uses FitNone or FitContain, whichever gives the smallest
+	// size, so the content is shrunk to fit but never enlarged.
+	FitScaleDown
+)
+
+// The String method returns a human-readable representation of the fit mode.
+func (m FitMode) String() string {
+	switch m {
+	case FitContain:
+		return "contain"
+	case FitCover:
+		return "cover"
+	case FitFill:
+		return "fill"
+	case FitNone:
+		return "none"
+	case FitScaleDown:
+		return "scale-down"
+	default:
+		return "unknown"
+	}
+}
+
+// FitSize returns the size of the content scaled to fit in the container with
+// the given mode.
+//
+// Content with an empty size cannot be scaled, its size is returned unchanged.
+func FitSize(content Size, container Size, mode FitMode) Size {
+	sx, sy := fitScale(content, container, mode)
+	return Size{
+		W: math.Abs(content.W) * sx,
+		H: math.Abs(content.H) * sy,
+	}
+}
+
+// FitRect places content of the given size in the container with the given
+// fit mode, and returns the rectangle where the content is drawn and the part
+// of the content which is visible.
+//
+// The alignment is the position of the content in the container when its size
+// differs from the size of the container, like the object-position property of
+// CSS.
+//
+// The dst rectangle is the area of the container covered by the content, and
+// the src rectangle is the area of the content, in a coordinate system where
+// the content spans from the origin to its size, which is drawn in dst. The
+// src rectangle is only smaller than the content with FitCover, FitNone, and
+// FitScaleDown, when parts of the content are outside of the container.
+func FitRect(content Size, container Rect, mode FitMode, align Alignment) (dst Rect, src Rect) {
+	container = container.Abs()
+	sx, sy := fitScale(content, container.Size(), mode)
+	w := math.Abs(content.W) * sx
+	h := math.Abs(content.H) * sy
+
+	placed := AlignSize(container, Size{W: w, H: h}, align)
+
+	if w == 0 || h == 0 {
+		return placed, Rect{}
+	}
+
+	if dst = placed.Intersect(container); dst.Empty() {
+		return dst, Rect{}
+	}
+
+	src = Rect{
+		X: (dst.X - placed.X) / sx,
+		Y: (dst.Y - placed.Y) / sy,
+		W: dst.W / sx,
+		H: dst.H / sy,
+	}
+	return
+}
+
+// fitScale returns the horizontal and vertical scale factors applied to the
+// content to fit it in the container.
+func fitScale(content Size, container Size, mode FitMode) (float64, float64) {
+	cw, ch := math.Abs(content.W), math.Abs(content.H)
+	w, h := math.Abs(container.W), math.Abs(container.H)
+
+	if cw == 0 || ch == 0 {
+		return 1, 1
+	}
+
+	switch mode {
+	case FitContain:
+		s := math.Min(w/cw, h/ch)
+		return s, s
+
+	case FitCover:
+		s := math.Max(w/cw, h/ch)
+		return s, s
+
+	case FitFill:
+		return w / cw, h / ch
+
+	case FitScaleDown:
+		s := math.Min(math.Min(w/cw, h/ch), 1)
+		return s, s
+
+	default:
+		return 1, 1
+	}
+}
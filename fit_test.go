@@ -0,0 +1,84 @@
+package geom
+
+import "testing"
+
+func TestFitModeString(t *testing.T) {
+	tests := []struct {
+		mode FitMode
+		str  string
+	}{
+		{FitContain, "contain"},
+		{FitCover, "cover"},
+		{FitFill, "fill"},
+		{FitNone, "none"},
+		{FitScaleDown, "scale-down"},
+		{FitMode(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		if s := test.mode.String(); s != test.str {
+			t.Errorf("%d: %s != %s", test.mode, s, test.str)
+		}
+	}
+}
+
+func TestFitSize(t *testing.T) {
+	tests := []struct {
+		content   Size
+		container Size
+		mode      FitMode
+		size      Size
+	}{
+		{Size{200, 100}, Size{100, 100}, FitContain, Size{100, 50}},
+		{Size{200, 100}, Size{100, 100}, FitCover, Size{200, 100}},
+		{Size{200, 100}, Size{100, 100}, FitFill, Size{100, 100}},
+		{Size{200, 100}, Size{100, 100}, FitNone, Size{200, 100}},
+		{Size{200, 100}, Size{100, 100}, FitScaleDown, Size{100, 50}},
+		{Size{20, 10}, Size{100, 100}, FitContain, Size{100, 50}},
+		{Size{20, 10}, Size{100, 100}, FitCover, Size{200, 100}},
+		{Size{20, 10}, Size{100, 100}, FitScaleDown, Size{20, 10}},
+		{Size{-20, 10}, Size{100, -100}, FitContain, Size{100, 50}},
+		{Size{0, 10}, Size{100, 100}, FitContain, Size{0, 10}},
+		{Size{20, 10}, Size{}, FitCover, Size{}},
+	}
+
+	for _, test := range tests {
+		if s := FitSize(test.content, test.container, test.mode); s != test.size {
+			t.Errorf("%s %s %s: %s != %s", test.mode, test.content, test.container, s, test.size)
+		}
+	}
+}
+
+func TestFitRect(t *testing.T) {
+	tests := []struct {
+		content   Size
+		container Rect
+		mode      FitMode
+		align     Alignment
+		dst       Rect
+		src       Rect
+	}{
+		{Size{200, 100}, Rect{10, 20, 100, 100}, FitContain, AlignCenter, Rect{10, 45, 100, 50}, Rect{0, 0, 200, 100}},
+		{Size{200, 100}, Rect{10, 20, 100, 100}, FitContain, AlignTopLeft, Rect{10, 20, 100, 50}, Rect{0, 0, 200, 100}},
+		{Size{200, 100}, Rect{10, 20, 100, 100}, FitContain, AlignBottomRight, Rect{10, 70, 100, 50}, Rect{0, 0, 200, 100}},
+		{Size{200, 100}, Rect{10, 20, 100, 100}, FitCover, AlignCenter, Rect{10, 20, 100, 100}, Rect{50, 0, 100, 100}},
+		{Size{200, 100}, Rect{10, 20, 100, 100}, FitCover, AlignTopLeft, Rect{10, 20, 100, 100}, Rect{0, 0, 100, 100}},
+		{Size{200, 100}, Rect{10, 20, 100, 100}, FitCover, AlignTopRight, Rect{10, 20, 100, 100}, Rect{100, 0, 100, 100}},
+		{Size{200, 100}, Rect{10, 20, 100, 100}, FitFill, AlignCenter, Rect{10, 20, 100, 100}, Rect{0, 0, 200, 100}},
+		{Size{200, 100}, Rect{10, 20, 100, 100}, FitNone, AlignCenter, Rect{10, 20, 100, 100}, Rect{50, 0, 100, 100}},
+		{Size{200, 100}, Rect{10, 20, 100, 100}, FitScaleDown, AlignCenter, Rect{10, 45, 100, 50}, Rect{0, 0, 200, 100}},
+		{Size{20, 10}, Rect{10, 20, 100, 100}, FitNone, AlignCenter, Rect{50, 65, 20, 10}, Rect{0, 0, 20, 10}},
+		{Size{20, 10}, Rect{10, 20, 100, 100}, FitScaleDown, AlignBottomRight, Rect{90, 110, 20, 10}, Rect{0, 0, 20, 10}},
+		{Size{20, 10}, Rect{110, 120, -100, -100}, FitCover, AlignCenter, Rect{10, 20, 100, 100}, Rect{5, 0, 10, 10}},
+		{Size{0, 10}, Rect{10, 20, 100, 100}, FitContain, AlignCenter, Rect{60, 65, 0, 10}, Rect{}},
+		{Size{20, 10}, Rect{10, 20, 100, 100}, FitNone, Alignment{2, 0}, Rect{}, Rect{}},
+	}
+
+	for _, test := range tests {
+		dst, src := FitRect(test.content, test.container, test.mode, test.align)
+
+		if !rectsNearlyEqual(dst, test.dst) || !rectsNearlyEqual(src, test.src) {
+			t.Errorf("%s %s %s %s: %s %s != %s %s", test.mode, test.content, test.container, test.align, dst, src, test.dst, test.src)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package geom
+
+import "math"
+
+// Flatten converts the path into a new path made only of MoveTo, LineTo and
+// ClosePath elements, where quadratic and cubic curves are approximated by line
+// segments using adaptive subdivision.
+//
+// The tolerance argument is the maximum distance allowed between the curves
+// and the line segments replacing them. When it is zero or negative, one
+// thousandth of the largest dimension of the path is used.
+func (p *Path) Flatten(tolerance float64) Path {
+	f := MakePath(len(p.Elements))
+
+	p.flatten(tolerance, func(op PathElementType, pts []Point) {
+		switch op {
+		case MoveTo:
+			f.MoveTo(pts[0])
+
+		case LineTo:
+			for _, pt := range pts {
+				f.LineTo(pt)
+			}
+
+		case ClosePath:
+			f.Close()
+		}
+	})
+
+	return f
+}
+
+// Polylines converts the path into a list of polylines, one for each sub-path,
+// where quadratic and cubic curves are approximated by line segments using
+// adaptive subdivision.
+//
+// The tolerance argument is the maximum distance allowed between the curves
+// and the line segments replacing them. When it is zero or negative, one
+// thousandth of the largest dimension of the path is used.
+//
+// When a sub-path is closed the returned polyline ends with a copy of its
+// first point.
+func (p *Path) Polylines(tolerance float64) [][]Point {
+	var lines [][]Point
+	var line []Point
+
+	flush := func() {
+		if len(line) != 0 {
+			lines = append(lines, line)
+		}
+		line = nil
+	}
+
+	p.flatten(tolerance, func(op PathElementType, pts []Point) {
+		switch op {
+		case MoveTo:
+			flush()
+			line = append(line, pts[0])
+
+		case LineTo:
+			line = append(line, pts...)
+
+		case ClosePath:
+			line = append(line, line[0])
+			flush()
+		}
+	})
+
+	flush()
+	return lines
+}
+
+// flatten walks through the elements of the path, calling emit with MoveTo,
+// LineTo and ClosePath operations that approximate the path with line
+// segments. The slice of points passed to emit is only valid until it returns.
+//
+// ClosePath operations are only emitted for sub-paths that have been started
+// with a MoveTo operation, and the position of the path after a ClosePath is
+// the first point of the sub-path that was closed.
+func (p *Path) flatten(tolerance float64, emit func(PathElementType, []Point)) {
+	if tolerance <= 0 {
+		tolerance = defaultTolerance(p.ControlBounds())
+	}
+
+	var buf []Point
+	var start Point
+	var last Point
+	var open bool
+
+	for _, e := range p.Elements {
+		switch e.Type {
+		case MoveTo:
+			start, last, open = e.Points[0], e.Points[0], true
+			emit(MoveTo, e.Points[:1])
+
+		case LineTo:
+			if !open {
+				open = true
+				emit(MoveTo, []Point{last})
+			}
+			last = e.Points[0]
+			emit(LineTo, e.Points[:1])
+
+		case QuadCurveTo:
+			if !open {
+				open = true
+				emit(MoveTo, []Point{last})
+			}
+			buf = flattenQuad(buf[:0], [3]Point{last, e.Points[0], e.Points[1]}, tolerance, 0)
+			last = e.Points[1]
+			emit(LineTo, buf)
+
+		case CubicCurveTo:
+			if !open {
+				open = true
+				emit(MoveTo, []Point{last})
+			}
+			buf = flattenCubic(buf[:0], [4]Point{last, e.Points[0], e.Points[1], e.Points[2]}, tolerance, 0)
+			last = e.Points[2]
+			emit(LineTo, buf)
+
+		case ClosePath:
+			if open {
+				open = false
+				last = start
+				emit(ClosePath, nil)
+			}
+		}
+	}
+}
+
+// defaultTolerance returns the tolerance used to flatten curves in an area of
+// the given bounds when the program didn't provide one, which is one thousandth
+// of its largest dimension.
+func defaultTolerance(bounds Rect) float64 {
+	if t := math.Max(math.Abs(bounds.W), math.Abs(bounds.H)) / 1000; t > 0 {
+		return t
+	}
+	return 1
+}
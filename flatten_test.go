@@ -0,0 +1,133 @@
+package geom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPathFlattenLines(t *testing.T) {
+	p := Rect{0, 0, 1, 1}.Path()
+	f := p.Flatten(0.1)
+
+	if !reflect.DeepEqual(p, f) {
+		t.Error("flattening a path made of lines must not modify it:", f)
+	}
+}
+
+func TestPathFlattenCurves(t *testing.T) {
+	const tolerance = 0.01
+
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.QuadCurveTo(Point{1, 2}, Point{2, 0})
+	p.CubicCurveTo(Point{2, -4}, Point{4, -4}, Point{4, 0})
+	p.Close()
+
+	f := p.Flatten(tolerance)
+
+	for i, e := range f.Elements {
+		if e.Type != MoveTo && e.Type != LineTo && e.Type != ClosePath {
+			t.Error("flattened path contains curve elements:", i, e)
+		}
+	}
+
+	if n := len(f.Elements); n <= len(p.Elements) {
+		t.Error("flattened path is missing line segments:", n)
+	}
+
+	if last := f.Elements[len(f.Elements)-2].Points[0]; last != (Point{4, 0}) {
+		t.Error("flattened path does not end where the original path ends:", last)
+	}
+
+	// Every point of the flattened path must be on the original curves, and
+	// the middle of each segment must be within the tolerance.
+	for i := 1; i < len(f.Elements)-1; i++ {
+		a := f.Elements[i-1].Points[0]
+		b := f.Elements[i].Points[0]
+
+		if d := distanceToCurves(p, a.Lerp(b, 0.5)); d > tolerance {
+			t.Error("flattened segment is too far from the original curves:", a, b, d)
+		}
+	}
+}
+
+func TestPathFlattenDefaultTolerance(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.CubicCurveTo(Point{0, 100}, Point{100, 100}, Point{100, 0})
+
+	// A zero tolerance used to subdivide curves to the maximum depth, the
+	// default tolerance for this path is 0.1.
+	for _, tolerance := range []float64{0, -1} {
+		f := p.Flatten(tolerance)
+
+		if n := len(f.Elements); n < 3 || n > 100 {
+			t.Error("invalid number of elements in the flattened path:", tolerance, n)
+		}
+
+		if !reflect.DeepEqual(f, p.Flatten(0.1)) {
+			t.Error("flattening with a non-positive tolerance must use the default tolerance:", tolerance)
+		}
+
+		if lines := p.Polylines(tolerance); !reflect.DeepEqual(lines, p.Polylines(0.1)) {
+			t.Error("polylines with a non-positive tolerance must use the default tolerance:", tolerance)
+		}
+	}
+}
+
+func TestPathPolylines(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.LineTo(Point{1, 0})
+	p.LineTo(Point{1, 1})
+	p.Close()
+	p.MoveTo(Point{2, 2})
+	p.QuadCurveTo(Point{3, 2}, Point{3, 2})
+
+	lines := p.Polylines(0.1)
+
+	if !reflect.DeepEqual(lines, [][]Point{
+		{{0, 0}, {1, 0}, {1, 1}, {0, 0}},
+		{{2, 2}, {3, 2}},
+	}) {
+		t.Error("invalid polylines:", lines)
+	}
+}
+
+func TestPathPolylinesEmpty(t *testing.T) {
+	p := Path{}
+
+	if lines := p.Polylines(0.1); lines != nil {
+		t.Error("polylines of an empty path must be nil:", lines)
+	}
+}
+
+// distanceToCurves approximates the distance between pt and the closest point
+// of the curves in p by sampling them.
+func distanceToCurves(p Path, pt Point) float64 {
+	d := pt.Distance(p.Elements[0].Points[0])
+
+	for i, e := range p.Elements {
+		p0 := p.lastPointAt(i - 1)
+
+		for j := 0; j <= 1000; j++ {
+			var c Point
+			t := float64(j) / 1000
+
+			switch e.Type {
+			case QuadCurveTo:
+				c = quadPoint(p0, e.Points[0], e.Points[1], t)
+			case CubicCurveTo:
+				c = cubicPoint(p0, e.Points[0], e.Points[1], e.Points[2], t)
+			default:
+				continue
+			}
+
+			if x := pt.Distance(c); x < d {
+				d = x
+			}
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,127 @@
+package geom
+
+import (
+	"image"
+	"math"
+)
+
+// RoundingMode is an enumeration of the ways floating point coordinates can be
+// converted to the integer coordinates used by the image package.
+type RoundingMode int
+
+const (
+	// RoundNearest rounds coordinates to the nearest integer, values half way
+	// between two integers are rounded up.
+	RoundNearest RoundingMode = iota
+
+	// RoundFloor rounds coordinates down.
+	RoundFloor
+
+	// RoundCeil rounds coordinates up.
+	RoundCeil
+
+	// RoundOutward rounds rectangles to the smallest integer rectangle that
+	// contains them, which covers every pixel they touch. Points are rounded
+	// to the nearest integer.
+	RoundOutward
+
+	// RoundInward rounds rectangles to the largest integer rectangle that they
+	// contain, which covers only the pixels they cover entirely. Points are
+	// rounded to the nearest integer.
+	RoundInward
+)
+
+// The String method returns a human-readable representation of the rounding
+// mode.
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundNearest:
+		return "nearest"
+	case RoundFloor:
+		return "floor"
+	case RoundCeil:
+		return "ceil"
+	case RoundOutward:
+		return "outward"
+	case RoundInward:
+		return "inward"
+	default:
+		return "unknown"
+	}
+}
+
+func (m RoundingMode) round(x float64) int {
+	switch m {
+	case RoundFloor:
+		return int(math.Floor(x))
+	case RoundCeil:
+		return int(math.Ceil(x))
+	default:
+		return int(math.Floor(x + 0.5))
+	}
+}
+
+// PointFromImage converts a point of the image package to a Point value.
+func PointFromImage(p image.Point) Point {
+	return Point{
+		X: float64(p.X),
+		Y: float64(p.Y),
+	}
+}
+
+// RectFromImage converts a rectangle of the image package to a Rect value.
+func RectFromImage(r image.Rectangle) Rect {
+	r = r.Canon()
+	return Rect{
+		X: float64(r.Min.X),
+		Y: float64(r.Min.Y),
+		W: float64(r.Dx()),
+		H: float64(r.Dy()),
+	}
+}
+
+// ImagePoint converts the point to a point of the image package, rounding its
+// coordinates according to the rounding mode given as argument.
+func (p Point) ImagePoint(mode RoundingMode) image.Point {
+	return image.Point{
+		X: mode.round(p.X),
+		Y: mode.round(p.Y),
+	}
+}
+
+// ImageRect converts the rectangle to a rectangle of the image package, rounding
+// its coordinates according to the rounding mode given as argument.
+//
+// Rectangles with negative dimensions are normalized first, and when rounding
+// inward a rectangle that doesn't contain any whole pixel the result is an
+// empty rectangle.
+func (r Rect) ImageRect(mode RoundingMode) image.Rectangle {
+	r = r.Abs()
+	x0, y0 := r.X, r.Y
+	x1, y1 := r.X+r.W, r.Y+r.H
+	var min, max image.Point
+
+	switch mode {
+	case RoundOutward:
+		min = image.Point{int(math.Floor(x0)), int(math.Floor(y0))}
+		max = image.Point{int(math.Ceil(x1)), int(math.Ceil(y1))}
+
+	case RoundInward:
+		min = image.Point{int(math.Ceil(x0)), int(math.Ceil(y0))}
+		max = image.Point{int(math.Floor(x1)), int(math.Floor(y1))}
+
+		if max.X < min.X {
+			max.X = min.X
+		}
+
+		if max.Y < min.Y {
+			max.Y = min.Y
+		}
+
+	default:
+		min = image.Point{mode.round(x0), mode.round(y0)}
+		max = image.Point{mode.round(x1), mode.round(y1)}
+	}
+
+	return image.Rectangle{Min: min, Max: max}
+}
@@ -0,0 +1,109 @@
+package geom
+
+import (
+	"image"
+	"testing"
+)
+
+func TestRoundingModeString(t *testing.T) {
+	tests := []struct {
+		mode RoundingMode
+		str  string
+	}{
+		{RoundNearest, "nearest"},
+		{RoundFloor, "floor"},
+		{RoundCeil, "ceil"},
+		{RoundOutward, "outward"},
+		{RoundInward, "inward"},
+		{RoundingMode(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		if s := test.mode.String(); s != test.str {
+			t.Errorf("%d: %s != %s", test.mode, s, test.str)
+		}
+	}
+}
+
+func TestPointFromImage(t *testing.T) {
+	if p := PointFromImage(image.Pt(-1, 2)); p != (Point{-1, 2}) {
+		t.Error("invalid point:", p)
+	}
+}
+
+func TestRectFromImage(t *testing.T) {
+	tests := []struct {
+		in  image.Rectangle
+		out Rect
+	}{
+		{image.Rect(1, 2, 4, 8), Rect{1, 2, 3, 6}},
+		{image.Rectangle{image.Pt(4, 8), image.Pt(1, 2)}, Rect{1, 2, 3, 6}},
+		{image.Rectangle{}, Rect{}},
+	}
+
+	for _, test := range tests {
+		if r := RectFromImage(test.in); r != test.out {
+			t.Errorf("%v: %s != %s", test.in, r, test.out)
+		}
+	}
+}
+
+func TestPointImagePoint(t *testing.T) {
+	p := Point{1.5, -1.5}
+
+	tests := []struct {
+		mode RoundingMode
+		out  image.Point
+	}{
+		{RoundNearest, image.Pt(2, -1)},
+		{RoundFloor, image.Pt(1, -2)},
+		{RoundCeil, image.Pt(2, -1)},
+		{RoundOutward, image.Pt(2, -1)},
+		{RoundInward, image.Pt(2, -1)},
+	}
+
+	for _, test := range tests {
+		if q := p.ImagePoint(test.mode); q != test.out {
+			t.Errorf("%s: %v != %v", test.mode, q, test.out)
+		}
+	}
+}
+
+func TestRectImageRect(t *testing.T) {
+	tests := []struct {
+		rect Rect
+		mode RoundingMode
+		out  image.Rectangle
+	}{
+		{Rect{0.25, 0.5, 2.5, 3.75}, RoundNearest, image.Rect(0, 1, 3, 4)},
+		{Rect{0.25, 0.5, 2.5, 3.75}, RoundFloor, image.Rect(0, 0, 2, 4)},
+		{Rect{0.25, 0.5, 2.5, 3.75}, RoundCeil, image.Rect(1, 1, 3, 5)},
+		{Rect{0.25, 0.5, 2.5, 3.75}, RoundOutward, image.Rect(0, 0, 3, 5)},
+		{Rect{0.25, 0.5, 2.5, 3.75}, RoundInward, image.Rect(1, 1, 2, 4)},
+		{Rect{-1.5, -1.5, 1, 1}, RoundOutward, image.Rect(-2, -2, 0, 0)},
+		{Rect{2.75, 2.75, -2.5, -2.5}, RoundOutward, image.Rect(0, 0, 3, 3)},
+		{Rect{1, 2, 3, 4}, RoundInward, image.Rect(1, 2, 4, 6)},
+		{Rect{0.25, 0.25, 0.5, 0.5}, RoundInward, image.Rectangle{image.Pt(1, 1), image.Pt(1, 1)}},
+	}
+
+	for _, test := range tests {
+		if r := test.rect.ImageRect(test.mode); r != test.out {
+			t.Errorf("%s (%s): %v != %v", test.rect, test.mode, r, test.out)
+		}
+	}
+}
+
+func TestRectImageRectDamage(t *testing.T) {
+	// Rounding outward must cover every pixel touched by the merged rectangles.
+	var list []Rect
+	list = MergeRect(list, Rect{0.5, 0.5, 2, 2})
+	list = MergeRect(list, Rect{10.25, 3.75, 0.5, 0.5})
+
+	for _, r := range list {
+		ir := r.ImageRect(RoundOutward)
+
+		if !RectFromImage(ir).ContainsRect(r) {
+			t.Errorf("%v doesn't cover %s", ir, r)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package geom
+
+import (
+	"fmt"
+	"image"
+)
+
+// The IntPoint type represents 2D integer coordinates, typically used for
+// pixel-exact computations.
+type IntPoint struct {
+	X int
+	Y int
+}
+
+// Zero checks if the receiver has the zero-value (both the x and y components
+// are zero).
+func (p IntPoint) Zero() bool {
+	return p.X == 0 && p.Y == 0
+}
+
+// The String method returns a human-readable representation of the point value.
+func (p IntPoint) String() string {
+	return fmt.Sprintf("(%d, %d)", p.X, p.Y)
+}
+
+// WithOrigin translate the receiver to a coordinate system with origin given as
+// argument and returns the modified point.
+func (p IntPoint) WithOrigin(origin IntPoint) IntPoint {
+	return IntPoint{
+		X: p.X - origin.X,
+		Y: p.Y - origin.Y,
+	}
+}
+
+// Add returns the sum of the receiver and the point given as argument.
+func (p IntPoint) Add(p1 IntPoint) IntPoint {
+	return IntPoint{
+		X: p.X + p1.X,
+		Y: p.Y + p1.Y,
+	}
+}
+
+// Sub returns the difference between the receiver and the point given as
+// argument.
+func (p IntPoint) Sub(p1 IntPoint) IntPoint {
+	return IntPoint{
+		X: p.X - p1.X,
+		Y: p.Y - p1.Y,
+	}
+}
+
+// Neg returns the opposite of the receiver.
+func (p IntPoint) Neg() IntPoint {
+	return IntPoint{
+		X: -p.X,
+		Y: -p.Y,
+	}
+}
+
+// Point converts the receiver to a Point value.
+func (p IntPoint) Point() Point {
+	return Point{
+		X: float64(p.X),
+		Y: float64(p.Y),
+	}
+}
+
+// ImagePoint converts the receiver to a point of the image package.
+func (p IntPoint) ImagePoint() image.Point {
+	return image.Point{
+		X: p.X,
+		Y: p.Y,
+	}
+}
+
+// IntPoint converts the point to an IntPoint value, rounding its coordinates
+// according to the rounding mode given as argument.
+func (p Point) IntPoint(mode RoundingMode) IntPoint {
+	return IntPoint{
+		X: mode.round(p.X),
+		Y: mode.round(p.Y),
+	}
+}
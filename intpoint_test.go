@@ -0,0 +1,61 @@
+package geom
+
+import (
+	"image"
+	"testing"
+)
+
+func TestIntPointZero(t *testing.T) {
+	if !(IntPoint{}).Zero() {
+		t.Error("IntPoint zero value was not detected by the Zero method")
+	}
+
+	if (IntPoint{X: 1}).Zero() {
+		t.Error("IntPoint non-zero value was not detected by the Zero method")
+	}
+}
+
+func TestIntPointString(t *testing.T) {
+	if s := (IntPoint{1, -2}).String(); s != "(1, -2)" {
+		t.Error("invalid string representation of IntPoint value:", s)
+	}
+}
+
+func TestIntPointWithOrigin(t *testing.T) {
+	if p := (IntPoint{1, 2}).WithOrigin(IntPoint{3, 5}); p != (IntPoint{-2, -3}) {
+		t.Error("invalid point returned by WithOrigin:", p)
+	}
+}
+
+func TestIntPointArithmetic(t *testing.T) {
+	p1 := IntPoint{1, 2}
+	p2 := IntPoint{3, 5}
+
+	if p := p1.Add(p2); p != (IntPoint{4, 7}) {
+		t.Error("invalid point returned by Add:", p)
+	}
+
+	if p := p1.Sub(p2); p != (IntPoint{-2, -3}) {
+		t.Error("invalid point returned by Sub:", p)
+	}
+
+	if p := p1.Neg(); p != (IntPoint{-1, -2}) {
+		t.Error("invalid point returned by Neg:", p)
+	}
+}
+
+func TestIntPointConversions(t *testing.T) {
+	p := IntPoint{1, -2}
+
+	if q := p.Point(); q != (Point{1, -2}) {
+		t.Error("invalid conversion to Point:", q)
+	}
+
+	if q := p.ImagePoint(); q != image.Pt(1, -2) {
+		t.Error("invalid conversion to image.Point:", q)
+	}
+
+	if q := (Point{1.5, -1.5}).IntPoint(RoundFloor); q != (IntPoint{1, -2}) {
+		t.Error("invalid conversion from Point:", q)
+	}
+}
@@ -0,0 +1,268 @@
+package geom
+
+import (
+	"fmt"
+	"image"
+)
+
+// The IntRect type represents a 2D rectangle with integer coordinates made of
+// an origin point, and width and height dimensions.
+//
+// It has the same API and behaves the same way as the Rect type, and is meant
+// to be used by pixel-exact code, like tracking damaged areas of windows or
+// packing textures.
+type IntRect struct {
+	X int
+	Y int
+	W int
+	H int
+}
+
+// MakeIntRect constructs an IntRect value from an origin and dimensions
+// provided as an IntPoint and IntSize value.
+func MakeIntRect(p IntPoint, s IntSize) IntRect {
+	return IntRect{
+		X: p.X,
+		Y: p.Y,
+		W: s.W,
+		H: s.H,
+	}
+}
+
+// IntRectFromImage converts a rectangle of the image package to an IntRect
+// value.
+func IntRectFromImage(r image.Rectangle) IntRect {
+	r = r.Canon()
+	return IntRect{
+		X: r.Min.X,
+		Y: r.Min.Y,
+		W: r.Dx(),
+		H: r.Dy(),
+	}
+}
+
+// SetOrigin changes the origin of the rectangle instance it is called on to the
+// IntPoint passed as argument.
+func (r *IntRect) SetOrigin(p IntPoint) {
+	r.X = p.X
+	r.Y = p.Y
+}
+
+// SetSize changes the dimensions of the rectangle instance it is called on to
+// the IntSize passed as argument.
+func (r *IntRect) SetSize(s IntSize) {
+	r.W = s.W
+	r.H = s.H
+}
+
+// Origin returns the current origin of the rectangle as an IntPoint value.
+func (r IntRect) Origin() IntPoint {
+	return IntPoint{
+		X: r.X,
+		Y: r.Y,
+	}
+}
+
+// Center returns the current center of the rectangle as an IntPoint value, the
+// coordinates are truncated when the dimensions are odd.
+func (r IntRect) Center() IntPoint {
+	return IntPoint{
+		X: r.X + (r.W / 2),
+		Y: r.Y + (r.H / 2),
+	}
+}
+
+// Tip returns the 'bottom-right' point of the rectangle as an IntPoint value.
+func (r IntRect) Tip() IntPoint {
+	return IntPoint{
+		X: r.X + r.W,
+		Y: r.Y + r.H,
+	}
+}
+
+// Size returns the dimensions of the rectangle as an IntSize value.
+func (r IntRect) Size() IntSize {
+	return IntSize{
+		W: r.W,
+		H: r.H,
+	}
+}
+
+// Abs transforms returns a rectangle equivalent to the one it is called on
+// where negative components of the dimensions have been converted to positive
+// values.
+func (r IntRect) Abs() IntRect {
+	if r.W < 0 {
+		r.X += r.W
+		r.W = -r.W
+	}
+
+	if r.H < 0 {
+		r.Y += r.H
+		r.H = -r.H
+	}
+
+	return r
+}
+
+// Intersect computes the intersection of the rectangle it's called on with the
+// one passed as argument, returning the result.
+//
+// The intersection is the area that is covered by both rectangles, which may be
+// a zero-value if the two don't overlap.
+func (r IntRect) Intersect(r1 IntRect) IntRect {
+	r = r.Abs()
+	r1 = r1.Abs()
+
+	x1 := maxInt(r.X, r1.X)
+	y1 := maxInt(r.Y, r1.Y)
+
+	x2 := minInt(r.X+r.W, r1.X+r1.W)
+	y2 := minInt(r.Y+r.H, r1.Y+r1.H)
+
+	if x1 > x2 || y1 > y2 {
+		return IntRect{}
+	}
+
+	return IntRect{
+		X: x1,
+		Y: y1,
+		W: x2 - x1,
+		H: y2 - y1,
+	}
+}
+
+// Merge computes and returns the smallest rectangle that includes both the one
+// it is called on and the one passed as argument.
+func (r IntRect) Merge(r1 IntRect) IntRect {
+	r = r.Abs()
+	r1 = r1.Abs()
+
+	x1 := minInt(r.X, r1.X)
+	y1 := minInt(r.Y, r1.Y)
+
+	x2 := maxInt(r.X+r.W, r1.X+r1.W)
+	y2 := maxInt(r.Y+r.H, r1.Y+r1.H)
+
+	return IntRect{
+		X: x1,
+		Y: y1,
+		W: x2 - x1,
+		H: y2 - y1,
+	}
+}
+
+// Zero checks whether the rectangle it is called on is the zero-value.
+func (r IntRect) Zero() bool {
+	return r.Origin().Zero() && r.Size().Zero()
+}
+
+// Empty method checks whether the rectangle is empty, which means it has a zero
+// area.
+func (r IntRect) Empty() bool {
+	return r.Size().Empty()
+}
+
+// Area computes and returns the area of the rectangle it is called on
+// (width x height).
+func (r IntRect) Area() int {
+	return r.Size().Area()
+}
+
+// ContainsPoint checks whether the point passed as argument is contained in the
+// rectangle it's called on, returning true when that's the case, false otherwise.
+func (r IntRect) ContainsPoint(p IntPoint) bool {
+	return r.X <= p.X && (r.X+r.W) > p.X && r.Y <= p.Y && (r.Y+r.H) > p.Y
+}
+
+// ContainsRect checks whether the rectangle passed as argument is contained in
+// the one it's called on, returning true when that's the case, false otherwise.
+func (r IntRect) ContainsRect(r1 IntRect) bool {
+	return r.X <= r1.X && (r.X+r.W) >= (r1.X+r1.W) && r.Y <= r1.Y && (r.Y+r.H) >= (r1.Y+r1.H)
+}
+
+// The String method returns a human-readable representation of the rectangle.
+func (r IntRect) String() string {
+	return fmt.Sprintf("{ %d, %d, %d, %d }", r.X, r.Y, r.W, r.H)
+}
+
+// Rect converts the receiver to a Rect value.
+func (r IntRect) Rect() Rect {
+	return Rect{
+		X: float64(r.X),
+		Y: float64(r.Y),
+		W: float64(r.W),
+		H: float64(r.H),
+	}
+}
+
+// ImageRect converts the receiver to a rectangle of the image package.
+func (r IntRect) ImageRect() image.Rectangle {
+	r = r.Abs()
+	return image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)
+}
+
+// Path satisfies the Shape interface, allowing IntRect values to be used with
+// programs that manipulate shapes.
+func (r IntRect) Path() Path {
+	return r.Rect().Path()
+}
+
+// IntRect converts the rectangle to an IntRect value, rounding its coordinates
+// according to the rounding mode given as argument, see Rect.ImageRect for
+// details.
+func (r Rect) IntRect(mode RoundingMode) IntRect {
+	return IntRectFromImage(r.ImageRect(mode))
+}
+
+// CenterIntRect computes and returns an IntRect value which represents the
+// `inner` rectangle centered in the `outer` rectangle.
+func CenterIntRect(outer IntRect, inner IntRect) IntRect {
+	return IntRect{
+		X: outer.X + ((outer.W / 2) - (inner.W / 2)),
+		Y: outer.Y + ((outer.H / 2) - (inner.H / 2)),
+		W: inner.W,
+		H: inner.H,
+	}
+}
+
+// MergeIntRect merges a rectangle into an existing list of other rectangles,
+// returing the potentially modified slice. It behaves like MergeRect, but works
+// on IntRect values.
+func MergeIntRect(list []IntRect, rect IntRect) []IntRect {
+	for _, r := range list {
+		if r.ContainsRect(rect) {
+			return list
+		}
+	}
+
+	for i, r := range list {
+		if !r.Intersect(rect).Empty() {
+			s := append(make([]IntRect, 0, len(list)), r.Merge(rect))
+
+			for j, r := range list {
+				if i != j {
+					s = MergeIntRect(s, r)
+				}
+			}
+
+			return s
+		}
+	}
+
+	return append(list, rect)
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,185 @@
+package geom
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+// intRectPairs is a list of pairs of rectangles covering the various ways two
+// rectangles can be positioned relative to each other.
+var intRectPairs = [][2]IntRect{
+	{{0, 0, 1, 1}, {2, 2, 1, 1}},
+	{{0, 0, 2, 2}, {1, 1, 2, 2}},
+	{{0, 0, 4, 4}, {1, 1, 2, 2}},
+	{{0, 0, 2, 2}, {2, 0, 2, 2}},
+	{{3, 3, -2, -2}, {0, 0, 2, 2}},
+	{{0, 0, 0, 0}, {-1, -1, 2, 2}},
+}
+
+func TestMakeIntRect(t *testing.T) {
+	if r := MakeIntRect(IntPoint{1, 2}, IntSize{3, 4}); r != (IntRect{1, 2, 3, 4}) {
+		t.Error("invalid rectangle returned by MakeIntRect:", r)
+	}
+}
+
+func TestIntRectFromImage(t *testing.T) {
+	if r := IntRectFromImage(image.Rectangle{image.Pt(4, 6), image.Pt(1, 2)}); r != (IntRect{1, 2, 3, 4}) {
+		t.Error("invalid conversion from image.Rectangle:", r)
+	}
+}
+
+func TestIntRectSetters(t *testing.T) {
+	r := IntRect{}
+	r.SetOrigin(IntPoint{1, 2})
+	r.SetSize(IntSize{3, 4})
+
+	if r != (IntRect{1, 2, 3, 4}) {
+		t.Error("invalid rectangle after setting origin and size:", r)
+	}
+}
+
+func TestIntRectGetters(t *testing.T) {
+	r := IntRect{1, 2, 3, 4}
+
+	if p := r.Origin(); p != (IntPoint{1, 2}) {
+		t.Error("invalid origin:", p)
+	}
+
+	if p := r.Center(); p != (IntPoint{2, 4}) {
+		t.Error("invalid center:", p)
+	}
+
+	if p := r.Tip(); p != (IntPoint{4, 6}) {
+		t.Error("invalid tip:", p)
+	}
+
+	if s := r.Size(); s != (IntSize{3, 4}) {
+		t.Error("invalid size:", s)
+	}
+}
+
+func TestIntRectAbs(t *testing.T) {
+	if r := (IntRect{1, 2, -3, -4}).Abs(); r != (IntRect{-2, -2, 3, 4}) {
+		t.Error("invalid rectangle returned by Abs:", r)
+	}
+}
+
+// TestIntRectMatchesRect verifies that the operations on IntRect values give
+// the same results as the same operations on Rect values.
+func TestIntRectMatchesRect(t *testing.T) {
+	for _, pair := range intRectPairs {
+		r1, r2 := pair[0], pair[1]
+		f1, f2 := r1.Rect(), r2.Rect()
+
+		if r := r1.Intersect(r2); r.Rect() != f1.Intersect(f2) {
+			t.Errorf("%s.Intersect(%s): %s != %s", r1, r2, r, f1.Intersect(f2))
+		}
+
+		if r := r1.Merge(r2); r.Rect() != f1.Merge(f2) {
+			t.Errorf("%s.Merge(%s): %s != %s", r1, r2, r, f1.Merge(f2))
+		}
+
+		if c := r1.ContainsRect(r2); c != f1.ContainsRect(f2) {
+			t.Errorf("%s.ContainsRect(%s): %t", r1, r2, c)
+		}
+
+		if c := r1.ContainsPoint(r2.Origin()); c != f1.ContainsPoint(f2.Origin()) {
+			t.Errorf("%s.ContainsPoint(%s): %t", r1, r2.Origin(), c)
+		}
+
+		if e := r1.Empty(); e != f1.Empty() {
+			t.Errorf("%s.Empty(): %t", r1, e)
+		}
+
+		if z := r1.Zero(); z != f1.Zero() {
+			t.Errorf("%s.Zero(): %t", r1, z)
+		}
+
+		if a := r1.Area(); float64(a) != f1.Area() {
+			t.Errorf("%s.Area(): %d", r1, a)
+		}
+
+		if l1, l2 := MergeIntRect([]IntRect{r1}, r2), MergeRect([]Rect{f1}, f2); len(l1) != len(l2) {
+			t.Errorf("MergeIntRect(%s, %s): %v != %v", r1, r2, l1, l2)
+		}
+	}
+}
+
+func TestIntRectString(t *testing.T) {
+	if s := (IntRect{1, 2, 3, 4}).String(); s != "{ 1, 2, 3, 4 }" {
+		t.Error("invalid string representation of IntRect value:", s)
+	}
+}
+
+func TestIntRectConversions(t *testing.T) {
+	r := IntRect{1, 2, 3, 4}
+
+	if f := r.Rect(); f != (Rect{1, 2, 3, 4}) {
+		t.Error("invalid conversion to Rect:", f)
+	}
+
+	if i := r.ImageRect(); i != image.Rect(1, 2, 4, 6) {
+		t.Error("invalid conversion to image.Rectangle:", i)
+	}
+
+	if i := (Rect{0.5, 0.5, 1, 1}).IntRect(RoundOutward); i != (IntRect{0, 0, 2, 2}) {
+		t.Error("invalid conversion from Rect:", i)
+	}
+
+	if p := r.Path(); !reflect.DeepEqual(p, r.Rect().Path()) {
+		t.Error("invalid path:", p)
+	}
+}
+
+func TestCenterIntRect(t *testing.T) {
+	if r := CenterIntRect(IntRect{0, 0, 10, 10}, IntRect{0, 0, 4, 5}); r != (IntRect{3, 3, 4, 5}) {
+		t.Error("invalid centered rectangle:", r)
+	}
+}
+
+func TestMergeIntRect(t *testing.T) {
+	tests := []struct {
+		key  string
+		rect IntRect
+		in   []IntRect
+		out  []IntRect
+	}{
+		{
+			key:  "empty slice",
+			rect: IntRect{0, 0, 1, 1},
+			in:   nil,
+			out:  []IntRect{{0, 0, 1, 1}},
+		},
+		{
+			key:  "non-empty slice, not contained, no intersections",
+			rect: IntRect{0, 0, 1, 1},
+			in:   []IntRect{{0, 2, 1, 1}},
+			out:  []IntRect{{0, 2, 1, 1}, {0, 0, 1, 1}},
+		},
+		{
+			key:  "non-empty slice, contained",
+			rect: IntRect{0, 0, 1, 1},
+			in:   []IntRect{{0, 0, 2, 2}},
+			out:  []IntRect{{0, 0, 2, 2}},
+		},
+		{
+			key:  "non-empty slice, intersects",
+			rect: IntRect{1, 1, 2, 2},
+			in:   []IntRect{{0, 0, 2, 2}},
+			out:  []IntRect{{0, 0, 3, 3}},
+		},
+		{
+			key:  "non-empty slice, re-intersects",
+			rect: IntRect{1, 1, 2, 2},
+			in:   []IntRect{{0, 0, 2, 2}, {2, 2, 2, 2}},
+			out:  []IntRect{{0, 0, 4, 4}},
+		},
+	}
+
+	for _, test := range tests {
+		if list := MergeIntRect(test.in, test.rect); !reflect.DeepEqual(list, test.out) {
+			t.Errorf("MergeIntRect: %s: %#v", test.key, list)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package geom
+
+import "fmt"
+
+// The IntSize type represents 2D integer sizes that are composed of a width and
+// height.
+type IntSize struct {
+	W int
+	H int
+}
+
+// Zero checks the size value and returns true if it is the zero-value, false
+// otherwise.
+func (s IntSize) Zero() bool {
+	return s.W == 0 && s.H == 0
+}
+
+// Empty checks whether the size of empty, which is true when either the width
+// or height are zero.
+func (s IntSize) Empty() bool {
+	return s.W == 0 || s.H == 0
+}
+
+// Area computes and returns the area of the size (width x height).
+func (s IntSize) Area() int {
+	return s.W * s.H
+}
+
+// Size converts the receiver to a Size value.
+func (s IntSize) Size() Size {
+	return Size{
+		W: float64(s.W),
+		H: float64(s.H),
+	}
+}
+
+// The String method returns a human-representation of the size value.
+func (s IntSize) String() string {
+	return fmt.Sprintf("[%d, %d]", s.W, s.H)
+}
@@ -0,0 +1,41 @@
+package geom
+
+import "testing"
+
+func TestIntSizeZero(t *testing.T) {
+	if !(IntSize{}).Zero() {
+		t.Error("IntSize zero value was not detected by the Zero method")
+	}
+
+	if (IntSize{W: 1}).Zero() {
+		t.Error("IntSize non-zero value was not detected by the Zero method")
+	}
+}
+
+func TestIntSizeEmpty(t *testing.T) {
+	if !(IntSize{W: 1}).Empty() {
+		t.Error("IntSize empty value was not detected by the Empty method")
+	}
+
+	if (IntSize{W: 1, H: 1}).Empty() {
+		t.Error("IntSize non-empty value was not detected by the Empty method")
+	}
+}
+
+func TestIntSizeArea(t *testing.T) {
+	if a := (IntSize{W: 2, H: 3}).Area(); a != 6 {
+		t.Error("invalid value returned by the Area method:", a)
+	}
+}
+
+func TestIntSizeSize(t *testing.T) {
+	if s := (IntSize{W: 2, H: 3}).Size(); s != (Size{2, 3}) {
+		t.Error("invalid conversion to Size:", s)
+	}
+}
+
+func TestIntSizeString(t *testing.T) {
+	if s := (IntSize{W: 2, H: 3}).String(); s != "[2, 3]" {
+		t.Error("invalid string representation of IntSize value:", s)
+	}
+}
@@ -0,0 +1,485 @@
+// Package layout implements algorithms that compute the positions and sizes of
+// rectangles, like the flexbox and grid layouts of CSS.
+package layout
+
+import (
+	"math"
+
+	"github.com/go-vu/geom"
+)
+
+// Direction is an enumeration of the directions in which a flex container
+// places its items.
+type Direction int
+
+const (
+	// Row places items horizontally from left to right.
+	Row Direction = iota
+
+	// Column places items vertically from top to bottom.
+	Column
+)
+
+// The String method returns a human-readable representation of the direction.
+func (d Direction) String() string {
+	switch d {
+	case Row:
+		return "row"
+	case Column:
+		return "column"
+	default:
+		return "unknown"
+	}
+}
+
+// Justify is an enumeration of the ways items are distributed along the main
+// axis of a container, like the justify-content property of CSS.
+type Justify int
+
+const (
+	// JustifyStart packs items at the start of the main axis.
+	JustifyStart Justify = iota
+
+	// JustifyEnd packs items at the end of the main axis.
+	JustifyEnd
+
+	// JustifyCenter packs items in the middle of the main axis.
+	JustifyCenter
+
+	// JustifySpaceBetween distributes the free space between items, the
+	// first and last items are placed at the edges of the container.
+	JustifySpaceBetween
+
+	// JustifySpaceAround distributes the free space around items, the space
+	// between two items is twice the space at the edges of the container.
+	JustifySpaceAround
+
+	// JustifySpaceEvenly distributes the free space evenly between items and
+	// the edges of the container.
+	JustifySpaceEvenly
+)
+
+// The String method returns a human-readable representation of the value.
+func (j Justify) String() string {
+	switch j {
+	case JustifyStart:
+		return "start"
+	case JustifyEnd:
+		return "end"
+	case JustifyCenter:
+		return "center"
+	case JustifySpaceBetween:
+		return "space-between"
+	case JustifySpaceAround:
+		return "space-around"
+	case JustifySpaceEvenly:
+		return "space-evenly"
+	default:
+		return "unknown"
+	}
+}
+
+// Align is an enumeration of the ways items are aligned on the cross axis of
+// their line, like the align-items property of CSS.
+type Align int
+
+const (
+	// AlignStretch stretches items which have no cross size to fill their
+	// line, other items are placed at the start of the line.
+	AlignStretch Align = iota
+
+	// AlignStart places items at the start of their line.
+	AlignStart
+
+	// AlignEnd places items at the end of their line.
+	AlignEnd
+
+	// AlignCenter places items in the middle of their line.
+	AlignCenter
+)
+
+// The String method returns a human-readable representation of the value.
+func (a Align) String() string {
+	switch a {
+	case AlignStretch:
+		return "stretch"
+	case AlignStart:
+		return "start"
+	case AlignEnd:
+		return "end"
+	case AlignCenter:
+		return "center"
+	default:
+		return "unknown"
+	}
+}
+
+// A FlexItem describes the sizing constraints of an item laid out by a Flex
+// container.
+type FlexItem struct {
+	// The preferred size of the item. The dimension on the main axis is the
+	// flex basis of the item, the size it has before growing or shrinking,
+	// and the dimension on the cross axis is its cross size, zero meaning
+	// that the item is stretched when the container uses AlignStretch.
+	Size geom.Size
+
+	// The minimum and maximum sizes of the item, a zero maximum dimension
+	// means that the dimension is unbounded.
+	Min geom.Size
+	Max geom.Size
+
+	// The factors used to distribute the free space of a line between its
+	// items when it is positive (Grow) or negative (Shrink). Unlike CSS the
+	// zero-value of Shrink means that the item doesn't shrink.
+	Grow   float64
+	Shrink float64
+
+	// The margin around the item, the rectangles returned by the layout are
+	// the areas inside the margins.
+	Margin geom.Margin
+}
+
+// The Flex type is a layout which implements a subset of the CSS flexible box
+// layout, placing items in lines along a main axis and distributing the free
+// space according to their grow and shrink factors.
+//
+// The zero-value is a valid layout which places items in a single row without
+// wrapping, packed at the start, and stretched vertically.
+type Flex struct {
+	// The direction of the main axis.
+	Direction Direction
+
+	// When true, items which don't fit on a line are placed on a new line.
+	Wrap bool
+
+	// How items are distributed along the main axis.
+	Justify Justify
+
+	// How items are aligned on the cross axis of their line.
+	Align Align
+
+	// The gap between items, the width is used between columns and the
+	// height between rows, whichever the direction is.
+	Gap geom.Size
+}
+
+// Layout computes the rectangles of the items placed in the container given as
+// argument, and returns them in the same order as the items.
+//
+// When the container has multiple lines, the lines take their natural cross
+// size and the remaining space of the container is distributed equally to
+// them, like the default align-content value of CSS. The layout is
+// deterministic: the same arguments always produce the same rectangles.
+func (f Flex) Layout(container geom.Rect, items []FlexItem) []geom.Rect {
+	rects := make([]geom.Rect, len(items))
+
+	if len(items) == 0 {
+		return rects
+	}
+
+	container = container.Abs()
+	mainSize, crossSize := f.main(container.Size()), f.cross(container.Size())
+	mainGap, crossGap := f.main(f.Gap), f.cross(f.Gap)
+
+	sizes := make([]float64, len(items))
+	lines := f.lines(items, mainSize, mainGap)
+
+	// Lines take the cross size of their tallest item, a single line without
+	// wrapping takes the cross size of the container.
+	crosses := make([]float64, len(lines))
+
+	for i, line := range lines {
+		f.resolve(items[line[0]:line[1]], sizes[line[0]:line[1]], mainSize, mainGap)
+
+		if !f.Wrap {
+			crosses[i] = crossSize
+			continue
+		}
+
+		for _, item := range items[line[0]:line[1]] {
+			crosses[i] = math.Max(crosses[i], f.itemCross(item, 0)+f.crossMargin(item.Margin))
+		}
+	}
+
+	extra := crossSize - crossGap*float64(len(lines)-1)
+
+	for _, c := range crosses {
+		extra -= c
+	}
+
+	if f.Wrap && extra > 0 {
+		for i := range crosses {
+			crosses[i] += extra / float64(len(crosses))
+		}
+	}
+
+	cross := 0.0
+
+	for i, line := range lines {
+		f.place(rects[line[0]:line[1]], items[line[0]:line[1]], sizes[line[0]:line[1]], mainSize, mainGap, cross, crosses[i])
+		cross += crosses[i] + crossGap
+	}
+
+	for i := range rects {
+		rects[i].X += container.X
+		rects[i].Y += container.Y
+	}
+
+	return rects
+}
+
+// lines splits the items in lines, returned as pairs of start and end indexes.
+func (f Flex) lines(items []FlexItem, mainSize float64, gap float64) [][2]int {
+	if !f.Wrap {
+		return [][2]int{{0, len(items)}}
+	}
+
+	var lines [][2]int
+	start := 0
+	used := 0.0
+
+	for i, item := range items {
+		size := f.hypothetical(item) + f.mainMargin(item.Margin)
+
+		if i > start && used+gap+size > mainSize {
+			lines = append(lines, [2]int{start, i})
+			start, used = i, 0
+		}
+
+		if i > start {
+			used += gap
+		}
+
+		used += size
+	}
+
+	return append(lines, [2]int{start, len(items)})
+}
+
+// resolve computes the main sizes of the items of a line, distributing the free
+// space according to their grow or shrink factors while honoring their minimum
+// and maximum sizes, as described in the "Resolving Flexible Lengths" section
+// of the CSS specification.
+func (f Flex) resolve(items []FlexItem, sizes []float64, mainSize float64, gap float64) {
+	frozen := make([]bool, len(items))
+	free := mainSize - gap*float64(len(items)-1)
+
+	for i, item := range items {
+		sizes[i] = f.hypothetical(item)
+		free -= sizes[i] + f.mainMargin(item.Margin)
+	}
+
+	grow := free > 0
+
+	for i, item := range items {
+		if (grow && item.Grow <= 0) || (!grow && item.Shrink <= 0) || free == 0 {
+			frozen[i] = true
+		}
+	}
+
+	for {
+		remaining := mainSize - gap*float64(len(items)-1)
+		factors := 0.0
+
+		for i, item := range items {
+			remaining -= f.mainMargin(item.Margin)
+
+			switch {
+			case frozen[i]:
+				remaining -= sizes[i]
+			case grow:
+				remaining -= f.basis(item)
+				factors += item.Grow
+			default:
+				remaining -= f.basis(item)
+				factors += item.Shrink * f.basis(item)
+			}
+		}
+
+		if factors == 0 {
+			return
+		}
+
+		violation := 0.0
+
+		for i, item := range items {
+			if frozen[i] {
+				continue
+			}
+
+			var target float64
+
+			if grow {
+				target = f.basis(item) + remaining*item.Grow/factors
+			} else {
+				target = f.basis(item) + remaining*item.Shrink*f.basis(item)/factors
+			}
+
+			sizes[i] = f.clampMain(item, target)
+			violation += sizes[i] - target
+		}
+
+		// Without violations every size is final, otherwise the items that
+		// were clamped in the direction of the total violation are frozen
+		// and the free space is distributed again to the other items.
+		done := true
+
+		for i, item := range items {
+			if frozen[i] {
+				continue
+			}
+
+			target := sizes[i]
+
+			switch {
+			case violation == 0:
+				frozen[i] = true
+			case violation > 0 && target == f.clampMain(item, math.Inf(-1)):
+				frozen[i] = true
+			case violation < 0 && target == f.clampMain(item, math.Inf(1)):
+				frozen[i] = true
+			default:
+				done = false
+			}
+		}
+
+		if done {
+			return
+		}
+	}
+}
+
+// place computes the rectangles of the items of a line, where the main sizes
+// of the items have already been resolved.
+func (f Flex) place(rects []geom.Rect, items []FlexItem, sizes []float64, mainSize float64, gap float64, cross float64, lineCross float64) {
+	free := mainSize - gap*float64(len(items)-1)
+
+	for i, item := range items {
+		free -= sizes[i] + f.mainMargin(item.Margin)
+	}
+
+	n := float64(len(items))
+	offset, between := 0.0, gap
+
+	if free > 0 {
+		switch f.Justify {
+		case JustifyEnd:
+			offset = free
+		case JustifyCenter:
+			offset = free / 2
+		case JustifySpaceBetween:
+			if len(items) > 1 {
+				between += free / (n - 1)
+			}
+		case JustifySpaceAround:
+			offset = free / (2 * n)
+			between += free / n
+		case JustifySpaceEvenly:
+			offset = free / (n + 1)
+			between += free / (n + 1)
+		}
+	}
+
+	pos := offset
+
+	for i, item := range items {
+		m := item.Margin
+		before, after := m.Left, m.Right
+		crossBefore := m.Top
+
+		if f.Direction == Column {
+			before, after = m.Top, m.Bottom
+			crossBefore = m.Left
+		}
+
+		size := f.itemCross(item, lineCross-f.crossMargin(m))
+		c := cross + crossBefore
+
+		switch f.Align {
+		case AlignEnd:
+			c = cross + lineCross - size - f.crossMargin(m) + crossBefore
+		case AlignCenter:
+			c = cross + (lineCross-size-f.crossMargin(m))/2 + crossBefore
+		}
+
+		pos += before
+
+		if f.Direction == Column {
+			rects[i] = geom.Rect{X: c, Y: pos, W: size, H: sizes[i]}
+		} else {
+			rects[i] = geom.Rect{X: pos, Y: c, W: sizes[i], H: size}
+		}
+
+		pos += sizes[i] + after + between
+	}
+}
+
+// itemCross returns the cross size of an item, stretched to the available size
+// when the layout uses AlignStretch and the item has no cross size.
+func (f Flex) itemCross(item FlexItem, available float64) float64 {
+	size := f.cross(item.Size)
+
+	if size == 0 && f.Align == AlignStretch {
+		size = available
+	}
+
+	return clamp(size, f.cross(item.Min), f.cross(item.Max))
+}
+
+func (f Flex) basis(item FlexItem) float64 {
+	return math.Max(f.main(item.Size), 0)
+}
+
+func (f Flex) hypothetical(item FlexItem) float64 {
+	return f.clampMain(item, f.basis(item))
+}
+
+func (f Flex) clampMain(item FlexItem, size float64) float64 {
+	return clamp(size, f.main(item.Min), f.main(item.Max))
+}
+
+func (f Flex) main(s geom.Size) float64 {
+	if f.Direction == Column {
+		return s.H
+	}
+	return s.W
+}
+
+func (f Flex) cross(s geom.Size) float64 {
+	if f.Direction == Column {
+		return s.W
+	}
+	return s.H
+}
+
+func (f Flex) mainMargin(m geom.Margin) float64 {
+	if f.Direction == Column {
+		return m.Height()
+	}
+	return m.Width()
+}
+
+func (f Flex) crossMargin(m geom.Margin) float64 {
+	if f.Direction == Column {
+		return m.Width()
+	}
+	return m.Height()
+}
+
+// clamp restricts the value to the range [min, max], where a zero max means
+// that there is no upper bound, and the result is never negative.
+func clamp(v float64, min float64, max float64) float64 {
+	if max > 0 && v > max {
+		v = max
+	}
+
+	if v < min {
+		v = min
+	}
+
+	if v < 0 {
+		v = 0
+	}
+
+	return v
+}
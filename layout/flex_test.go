@@ -0,0 +1,345 @@
+package layout
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-vu/geom"
+)
+
+func rect(x float64, y float64, w float64, h float64) geom.Rect {
+	return geom.Rect{X: x, Y: y, W: w, H: h}
+}
+
+func rectsNearlyEqual(r1 []geom.Rect, r2 []geom.Rect) bool {
+	if len(r1) != len(r2) {
+		return false
+	}
+
+	for i := range r1 {
+		a, b := r1[i], r2[i]
+
+		if math.Abs(a.X-b.X) > 1e-9 || math.Abs(a.Y-b.Y) > 1e-9 || math.Abs(a.W-b.W) > 1e-9 || math.Abs(a.H-b.H) > 1e-9 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestEnumStrings(t *testing.T) {
+	tests := []struct {
+		value interface{ String() string }
+		str   string
+	}{
+		{Row, "row"},
+		{Column, "column"},
+		{Direction(-1), "unknown"},
+		{JustifyStart, "start"},
+		{JustifyEnd, "end"},
+		{JustifyCenter, "center"},
+		{JustifySpaceBetween, "space-between"},
+		{JustifySpaceAround, "space-around"},
+		{JustifySpaceEvenly, "space-evenly"},
+		{Justify(-1), "unknown"},
+		{AlignStretch, "stretch"},
+		{AlignStart, "start"},
+		{AlignEnd, "end"},
+		{AlignCenter, "center"},
+		{Align(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		if s := test.value.String(); s != test.str {
+			t.Errorf("%#v: %s != %s", test.value, s, test.str)
+		}
+	}
+}
+
+func TestFlexLayout(t *testing.T) {
+	container := geom.Rect{X: 0, Y: 0, W: 100, H: 50}
+	fixed := []FlexItem{
+		{Size: geom.Size{W: 10, H: 10}},
+		{Size: geom.Size{W: 10, H: 20}},
+		{Size: geom.Size{W: 10, H: 30}},
+	}
+
+	tests := []struct {
+		key   string
+		flex  Flex
+		items []FlexItem
+		rects []geom.Rect
+	}{
+		{
+			key:   "no items",
+			items: nil,
+			rects: []geom.Rect{},
+		},
+		{
+			key:   "fixed sizes",
+			flex:  Flex{Align: AlignStart},
+			items: fixed,
+			rects: []geom.Rect{rect(0, 0, 10, 10), rect(10, 0, 10, 20), rect(20, 0, 10, 30)},
+		},
+		{
+			key:  "grow",
+			flex: Flex{},
+			items: []FlexItem{
+				{Grow: 1},
+				{Grow: 3},
+			},
+			rects: []geom.Rect{rect(0, 0, 25, 50), rect(25, 0, 75, 50)},
+		},
+		{
+			key:  "grow from basis",
+			flex: Flex{},
+			items: []FlexItem{
+				{Size: geom.Size{W: 40}, Grow: 1},
+				{Size: geom.Size{W: 20}, Grow: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 60, 50), rect(60, 0, 40, 50)},
+		},
+		{
+			key:  "grow with maximum size",
+			flex: Flex{},
+			items: []FlexItem{
+				{Grow: 1, Max: geom.Size{W: 10}},
+				{Grow: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 10, 50), rect(10, 0, 90, 50)},
+		},
+		{
+			key:  "shrink",
+			flex: Flex{},
+			items: []FlexItem{
+				{Size: geom.Size{W: 120}, Shrink: 1},
+				{Size: geom.Size{W: 80}, Shrink: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 60, 50), rect(60, 0, 40, 50)},
+		},
+		{
+			key:  "shrink with minimum size",
+			flex: Flex{},
+			items: []FlexItem{
+				{Size: geom.Size{W: 120}, Shrink: 1, Min: geom.Size{W: 90}},
+				{Size: geom.Size{W: 80}, Shrink: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 90, 50), rect(90, 0, 10, 50)},
+		},
+		{
+			key:  "no shrink overflows",
+			flex: Flex{},
+			items: []FlexItem{
+				{Size: geom.Size{W: 80}},
+				{Size: geom.Size{W: 80}, Shrink: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 80, 50), rect(80, 0, 20, 50)},
+		},
+		{
+			key:   "justify end",
+			flex:  Flex{Justify: JustifyEnd, Align: AlignStart},
+			items: fixed,
+			rects: []geom.Rect{rect(70, 0, 10, 10), rect(80, 0, 10, 20), rect(90, 0, 10, 30)},
+		},
+		{
+			key:   "justify center",
+			flex:  Flex{Justify: JustifyCenter, Align: AlignStart},
+			items: fixed,
+			rects: []geom.Rect{rect(35, 0, 10, 10), rect(45, 0, 10, 20), rect(55, 0, 10, 30)},
+		},
+		{
+			key:   "justify space between",
+			flex:  Flex{Justify: JustifySpaceBetween, Align: AlignStart},
+			items: fixed,
+			rects: []geom.Rect{rect(0, 0, 10, 10), rect(45, 0, 10, 20), rect(90, 0, 10, 30)},
+		},
+		{
+			key:   "justify space around",
+			flex:  Flex{Justify: JustifySpaceAround, Align: AlignStart},
+			items: fixed,
+			rects: []geom.Rect{rect(70.0/6, 0, 10, 10), rect(45, 0, 10, 20), rect(90-70.0/6, 0, 10, 30)},
+		},
+		{
+			key:   "justify space evenly",
+			flex:  Flex{Justify: JustifySpaceEvenly, Align: AlignStart},
+			items: fixed,
+			rects: []geom.Rect{rect(17.5, 0, 10, 10), rect(45, 0, 10, 20), rect(72.5, 0, 10, 30)},
+		},
+		{
+			key:   "justify space between single item",
+			flex:  Flex{Justify: JustifySpaceBetween, Align: AlignStart},
+			items: fixed[:1],
+			rects: []geom.Rect{rect(0, 0, 10, 10)},
+		},
+		{
+			key:   "align end",
+			flex:  Flex{Align: AlignEnd},
+			items: fixed,
+			rects: []geom.Rect{rect(0, 40, 10, 10), rect(10, 30, 10, 20), rect(20, 20, 10, 30)},
+		},
+		{
+			key:   "align center",
+			flex:  Flex{Align: AlignCenter},
+			items: fixed,
+			rects: []geom.Rect{rect(0, 20, 10, 10), rect(10, 15, 10, 20), rect(20, 10, 10, 30)},
+		},
+		{
+			key:  "align stretch",
+			flex: Flex{Align: AlignStretch},
+			items: []FlexItem{
+				{Size: geom.Size{W: 10}},
+				{Size: geom.Size{W: 10, H: 20}},
+				{Size: geom.Size{W: 10}, Max: geom.Size{H: 30}},
+			},
+			rects: []geom.Rect{rect(0, 0, 10, 50), rect(10, 0, 10, 20), rect(20, 0, 10, 30)},
+		},
+		{
+			key:   "gap",
+			flex:  Flex{Gap: geom.Size{W: 5, H: 100}, Align: AlignStart},
+			items: fixed,
+			rects: []geom.Rect{rect(0, 0, 10, 10), rect(15, 0, 10, 20), rect(30, 0, 10, 30)},
+		},
+		{
+			key:  "gap with grow",
+			flex: Flex{Gap: geom.Size{W: 10}},
+			items: []FlexItem{
+				{Grow: 1},
+				{Grow: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 45, 50), rect(55, 0, 45, 50)},
+		},
+		{
+			key:  "margins",
+			flex: Flex{},
+			items: []FlexItem{
+				{Size: geom.Size{W: 10}, Margin: geom.Margin{Top: 2, Bottom: 3, Left: 5, Right: 5}},
+				{Grow: 1, Margin: geom.Margin{Left: 10}},
+			},
+			rects: []geom.Rect{rect(5, 2, 10, 45), rect(30, 0, 70, 50)},
+		},
+		{
+			key:  "margins with alignment",
+			flex: Flex{Justify: JustifyEnd, Align: AlignEnd},
+			items: []FlexItem{
+				{Size: geom.Size{W: 10, H: 10}, Margin: geom.Margin{Bottom: 5, Right: 5}},
+			},
+			rects: []geom.Rect{rect(85, 35, 10, 10)},
+		},
+		{
+			key:  "column",
+			flex: Flex{Direction: Column, Justify: JustifyCenter, Align: AlignCenter},
+			items: []FlexItem{
+				{Size: geom.Size{W: 10, H: 10}},
+				{Size: geom.Size{W: 20, H: 20}},
+			},
+			rects: []geom.Rect{rect(45, 10, 10, 10), rect(40, 20, 20, 20)},
+		},
+		{
+			key:  "column grow and stretch",
+			flex: Flex{Direction: Column, Gap: geom.Size{W: 100, H: 10}},
+			items: []FlexItem{
+				{Size: geom.Size{H: 10}},
+				{Grow: 1, Margin: geom.Margin{Left: 5}},
+			},
+			rects: []geom.Rect{rect(0, 0, 100, 10), rect(5, 20, 95, 30)},
+		},
+	}
+
+	for _, test := range tests {
+		if rects := test.flex.Layout(container, test.items); !rectsNearlyEqual(rects, test.rects) {
+			t.Errorf("%s: %v != %v", test.key, rects, test.rects)
+		}
+	}
+}
+
+func TestFlexWrap(t *testing.T) {
+	container := geom.Rect{X: 10, Y: 20, W: 100, H: 100}
+	items := []FlexItem{
+		{Size: geom.Size{W: 40, H: 20}},
+		{Size: geom.Size{W: 40, H: 10}},
+		{Size: geom.Size{W: 40, H: 20}, Grow: 1},
+	}
+
+	tests := []struct {
+		key   string
+		flex  Flex
+		rects []geom.Rect
+	}{
+		{
+			key:  "row",
+			flex: Flex{Wrap: true, Gap: geom.Size{W: 10, H: 5}},
+			rects: []geom.Rect{
+				rect(10, 20, 40, 20),
+				rect(60, 20, 40, 10),
+				rect(10, 72.5, 100, 20),
+			},
+		},
+		{
+			key:  "row aligned to the end",
+			flex: Flex{Wrap: true, Gap: geom.Size{W: 10, H: 5}, Align: AlignEnd},
+			rects: []geom.Rect{
+				rect(10, 47.5, 40, 20),
+				rect(60, 57.5, 40, 10),
+				rect(10, 100, 100, 20),
+			},
+		},
+		{
+			key:  "column",
+			flex: Flex{Direction: Column, Wrap: true, Align: AlignStart},
+			rects: []geom.Rect{
+				rect(10, 20, 40, 20),
+				rect(10, 40, 40, 10),
+				rect(10, 50, 40, 70),
+			},
+		},
+		{
+			key:  "without wrapping",
+			flex: Flex{Align: AlignStart, Gap: geom.Size{W: 10}},
+			rects: []geom.Rect{
+				rect(10, 20, 40, 20),
+				rect(60, 20, 40, 10),
+				rect(110, 20, 40, 20),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		if rects := test.flex.Layout(container, items); !rectsNearlyEqual(rects, test.rects) {
+			t.Errorf("%s: %v != %v", test.key, rects, test.rects)
+		}
+	}
+}
+
+func TestFlexWrapOversizedItem(t *testing.T) {
+	// Items larger than the container are placed alone on their line.
+	items := []FlexItem{
+		{Size: geom.Size{W: 10, H: 10}},
+		{Size: geom.Size{W: 200, H: 10}},
+		{Size: geom.Size{W: 10, H: 10}},
+	}
+
+	f := Flex{Wrap: true, Align: AlignStart}
+	rects := f.Layout(geom.Rect{W: 100, H: 30}, items)
+	expected := []geom.Rect{rect(0, 0, 10, 10), rect(0, 10, 200, 10), rect(0, 20, 10, 10)}
+
+	if !rectsNearlyEqual(rects, expected) {
+		t.Errorf("%v != %v", rects, expected)
+	}
+}
+
+func TestFlexDeterministic(t *testing.T) {
+	items := []FlexItem{
+		{Size: geom.Size{W: 13, H: 7}, Grow: 0.3, Shrink: 1, Margin: geom.MakeMargin(1.5)},
+		{Size: geom.Size{W: 31}, Grow: 1.7, Max: geom.Size{W: 40}},
+		{Size: geom.Size{W: 23, H: 11}, Shrink: 2, Min: geom.Size{W: 5}},
+	}
+
+	f := Flex{Wrap: true, Justify: JustifySpaceAround, Align: AlignCenter, Gap: geom.Size{W: 3, H: 2}}
+	r1 := f.Layout(geom.Rect{W: 77, H: 33}, items)
+
+	for i := 0; i < 10; i++ {
+		if r2 := f.Layout(geom.Rect{W: 77, H: 33}, items); !rectsNearlyEqual(r1, r2) {
+			t.Fatalf("the layout is not deterministic: %v != %v", r1, r2)
+		}
+	}
+}
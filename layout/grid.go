@@ -0,0 +1,379 @@
+package layout
+
+import (
+	"sort"
+
+	"github.com/go-vu/geom"
+)
+
+// TrackSizing is an enumeration of the ways the bounds of grid tracks can be
+// sized.
+type TrackSizing int
+
+const (
+	// TrackAuto sizes a track from the size hints of the items it contains,
+	// and lets it stretch when the grid has free space.
+	TrackAuto TrackSizing = iota
+
+	// TrackFixed sizes a track with an explicit length.
+	TrackFixed
+
+	// TrackFraction sizes a track with a share of the free space of the
+	// grid, it is only meaningful as the maximum bound of a track, and is
+	// treated as TrackAuto when used as the minimum bound.
+	TrackFraction
+)
+
+// The String method returns a human-readable representation of the value.
+func (s TrackSizing) String() string {
+	switch s {
+	case TrackAuto:
+		return "auto"
+	case TrackFixed:
+		return "fixed"
+	case TrackFraction:
+		return "fraction"
+	default:
+		return "unknown"
+	}
+}
+
+// A TrackSize is one of the bounds of the size of a grid track.
+type TrackSize struct {
+	Sizing TrackSizing
+
+	// The length of TrackFixed bounds, or the flex factor of TrackFraction
+	// bounds.
+	Value float64
+}
+
+// A Track describes the size of a row or a column of a grid, which is
+// computed between a minimum and a maximum bound, like the minmax() function
+// of CSS.
+type Track struct {
+	Min TrackSize
+	Max TrackSize
+}
+
+// FixedTrack returns a track with an explicit size.
+func FixedTrack(size float64) Track {
+	return Track{
+		Min: TrackSize{Sizing: TrackFixed, Value: size},
+		Max: TrackSize{Sizing: TrackFixed, Value: size},
+	}
+}
+
+// FractionTrack returns a track taking a share of the free space of the grid
+// proportional to the flex factor fr, but never smaller than its content.
+func FractionTrack(fr float64) Track {
+	return Track{
+		Max: TrackSize{Sizing: TrackFraction, Value: fr},
+	}
+}
+
+// AutoTrack returns a track sized from the size hints of the items it contains.
+func AutoTrack() Track {
+	return Track{}
+}
+
+// A GridItem describes the position and sizing constraints of an item laid out
+// by a Grid.
+type GridItem struct {
+	// The zero-based indexes of the first column and row of the item.
+	Column int
+	Row    int
+
+	// The number of columns and rows covered by the item, zero is treated as
+	// one.
+	ColumnSpan int
+	RowSpan    int
+
+	// The size hint of the item, which is used to size the auto tracks that
+	// contain it.
+	Size geom.Size
+
+	// The margin around the item, the rectangles returned by the layout are
+	// the areas of the items inside the margins.
+	Margin geom.Margin
+}
+
+// The Grid type is a layout which implements a subset of the CSS grid layout,
+// dividing a container in rows and columns where items are placed.
+//
+// Items are stretched to fill the area made of the cells that they cover.
+// Items placed beyond the last declared row or column create implicit auto
+// tracks.
+type Grid struct {
+	Columns []Track
+	Rows    []Track
+
+	// The gap between tracks, the width is used between columns and the
+	// height between rows.
+	Gap geom.Size
+}
+
+// Layout computes the rectangles of the items placed in the container given as
+// argument, and returns them in the same order as the items.
+func (g Grid) Layout(container geom.Rect, items []GridItem) []geom.Rect {
+	container = container.Abs()
+	rects := make([]geom.Rect, len(items))
+
+	if len(items) == 0 {
+		return rects
+	}
+
+	columns := make([]gridSpan, len(items))
+	rows := make([]gridSpan, len(items))
+
+	for i, item := range items {
+		columns[i] = makeGridSpan(item.Column, item.ColumnSpan, item.Size.W+item.Margin.Width())
+		rows[i] = makeGridSpan(item.Row, item.RowSpan, item.Size.H+item.Margin.Height())
+	}
+
+	xs := sizeTracks(g.Columns, columns, container.W, g.Gap.W)
+	ys := sizeTracks(g.Rows, rows, container.H, g.Gap.H)
+
+	for i, item := range items {
+		c, r := columns[i], rows[i]
+		x0, x1 := trackRange(xs, c, g.Gap.W)
+		y0, y1 := trackRange(ys, r, g.Gap.H)
+		rects[i] = item.Margin.ShrinkRect(geom.Rect{
+			X: container.X + x0,
+			Y: container.Y + y0,
+			W: x1 - x0,
+			H: y1 - y0,
+		})
+	}
+
+	return rects
+}
+
+// gridSpan represents the tracks covered by an item on one axis, and the size
+// that the item needs on that axis.
+type gridSpan struct {
+	start int
+	end   int
+	size  float64
+}
+
+func makeGridSpan(start int, span int, size float64) gridSpan {
+	if start < 0 {
+		start = 0
+	}
+
+	if span < 1 {
+		span = 1
+	}
+
+	return gridSpan{start: start, end: start + span, size: size}
+}
+
+// trackRange returns the start and end offsets of the tracks covered by the
+// span.
+func trackRange(sizes []float64, s gridSpan, gap float64) (float64, float64) {
+	pos := 0.0
+
+	for i := 0; i < s.start; i++ {
+		pos += sizes[i] + gap
+	}
+
+	end := pos
+
+	for i := s.start; i < s.end; i++ {
+		if i != s.start {
+			end += gap
+		}
+		end += sizes[i]
+	}
+
+	return pos, end
+}
+
+// sizeTracks computes the sizes of the tracks of one axis of the grid, which
+// is a simplified version of the track sizing algorithm of CSS grids.
+func sizeTracks(declared []Track, spans []gridSpan, available float64, gap float64) []float64 {
+	n := len(declared)
+
+	for _, s := range spans {
+		if s.end > n {
+			n = s.end
+		}
+	}
+
+	tracks := make([]Track, n)
+	copy(tracks, declared)
+
+	base := make([]float64, n)
+	limit := make([]float64, n)
+
+	for i, t := range tracks {
+		if t.Min.Sizing == TrackFixed {
+			base[i] = t.Min.Value
+		}
+
+		if t.Max.Sizing == TrackFixed {
+			limit[i] = t.Max.Value
+		}
+	}
+
+	// Items spanning a single track are handled first, then items spanning
+	// more tracks distribute the size they need that isn't already covered
+	// by the tracks they span.
+	sorted := make([]gridSpan, len(spans))
+	copy(sorted, spans)
+	sort.SliceStable(sorted, func(i int, j int) bool {
+		return sorted[i].end-sorted[i].start < sorted[j].end-sorted[j].start
+	})
+
+	for _, s := range sorted {
+		if s.end-s.start == 1 {
+			i := s.start
+
+			if tracks[i].Min.Sizing != TrackFixed && s.size > base[i] {
+				base[i] = s.size
+			}
+
+			if tracks[i].Max.Sizing == TrackAuto && s.size > limit[i] {
+				limit[i] = s.size
+			}
+
+			continue
+		}
+
+		extra := s.size - gap*float64(s.end-s.start-1)
+		var auto []int
+
+		for i := s.start; i < s.end; i++ {
+			extra -= base[i]
+
+			if tracks[i].Min.Sizing != TrackFixed {
+				auto = append(auto, i)
+			}
+		}
+
+		if extra > 0 && len(auto) != 0 {
+			for _, i := range auto {
+				base[i] += extra / float64(len(auto))
+			}
+		}
+	}
+
+	free := available - gap*float64(n-1)
+	frs := 0.0
+
+	for i, t := range tracks {
+		if limit[i] < base[i] {
+			limit[i] = base[i]
+		}
+
+		free -= base[i]
+
+		if t.Max.Sizing == TrackFraction {
+			frs += t.Max.Value
+		}
+	}
+
+	// Tracks that aren't flexible grow up to their limits, by the same amount
+	// as long as there is free space.
+	for free > 0 {
+		var growing []int
+
+		for i, t := range tracks {
+			if t.Max.Sizing != TrackFraction && base[i] < limit[i] {
+				growing = append(growing, i)
+			}
+		}
+
+		if len(growing) == 0 {
+			break
+		}
+
+		share := free / float64(len(growing))
+		last := true
+
+		for _, i := range growing {
+			if d := limit[i] - base[i]; d < share {
+				share, last = d, false
+			}
+		}
+
+		for _, i := range growing {
+			base[i] += share
+		}
+
+		if last {
+			free = 0
+			break
+		}
+
+		free -= share * float64(len(growing))
+	}
+
+	if frs > 0 {
+		expandFlexibleTracks(tracks, base, free)
+		return base
+	}
+
+	// Without flexible tracks, the remaining free space stretches auto
+	// tracks.
+	if free > 0 {
+		var auto []int
+
+		for i, t := range tracks {
+			if t.Max.Sizing == TrackAuto {
+				auto = append(auto, i)
+			}
+		}
+
+		for _, i := range auto {
+			base[i] += free / float64(len(auto))
+		}
+	}
+
+	return base
+}
+
+// expandFlexibleTracks distributes the free space to the tracks with a
+// TrackFraction maximum bound. Tracks which are already larger than their share
+// of the space keep their size and don't take part in the distribution.
+func expandFlexibleTracks(tracks []Track, base []float64, free float64) {
+	inflexible := make([]bool, len(tracks))
+
+	for {
+		space := free
+		frs := 0.0
+
+		for i, t := range tracks {
+			if t.Max.Sizing == TrackFraction && !inflexible[i] {
+				space += base[i]
+				frs += t.Max.Value
+			}
+		}
+
+		if frs <= 0 || space <= 0 {
+			return
+		}
+
+		fr := space / frs
+		done := true
+
+		for i, t := range tracks {
+			if t.Max.Sizing == TrackFraction && !inflexible[i] && base[i] > fr*t.Max.Value {
+				inflexible[i] = true
+				done = false
+			}
+		}
+
+		if !done {
+			continue
+		}
+
+		for i, t := range tracks {
+			if t.Max.Sizing == TrackFraction && !inflexible[i] {
+				base[i] = fr * t.Max.Value
+			}
+		}
+
+		return
+	}
+}
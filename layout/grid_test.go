@@ -0,0 +1,207 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/go-vu/geom"
+)
+
+func TestTrackSizingString(t *testing.T) {
+	tests := []struct {
+		sizing TrackSizing
+		str    string
+	}{
+		{TrackAuto, "auto"},
+		{TrackFixed, "fixed"},
+		{TrackFraction, "fraction"},
+		{TrackSizing(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		if s := test.sizing.String(); s != test.str {
+			t.Errorf("%d: %s != %s", test.sizing, s, test.str)
+		}
+	}
+}
+
+func TestTrackConstructors(t *testing.T) {
+	if tr := FixedTrack(10); tr != (Track{TrackSize{TrackFixed, 10}, TrackSize{TrackFixed, 10}}) {
+		t.Error("invalid fixed track:", tr)
+	}
+
+	if tr := FractionTrack(2); tr != (Track{TrackSize{TrackAuto, 0}, TrackSize{TrackFraction, 2}}) {
+		t.Error("invalid fraction track:", tr)
+	}
+
+	if tr := AutoTrack(); tr != (Track{}) {
+		t.Error("invalid auto track:", tr)
+	}
+}
+
+func TestGridLayout(t *testing.T) {
+	container := geom.Rect{X: 0, Y: 0, W: 250, H: 100}
+
+	tests := []struct {
+		key   string
+		grid  Grid
+		items []GridItem
+		rects []geom.Rect
+	}{
+		{
+			key:   "no items",
+			grid:  Grid{Columns: []Track{FixedTrack(10)}},
+			items: nil,
+			rects: []geom.Rect{},
+		},
+		{
+			key: "fixed tracks",
+			grid: Grid{
+				Columns: []Track{FixedTrack(100), FixedTrack(50)},
+				Rows:    []Track{FixedTrack(20), FixedTrack(30)},
+			},
+			items: []GridItem{
+				{Column: 0, Row: 0},
+				{Column: 1, Row: 0},
+				{Column: 1, Row: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 100, 20), rect(100, 0, 50, 20), rect(100, 20, 50, 30)},
+		},
+		{
+			key: "fractions and gaps",
+			grid: Grid{
+				Columns: []Track{FixedTrack(50), FractionTrack(1), FractionTrack(3)},
+				Rows:    []Track{FractionTrack(1), FractionTrack(1)},
+				Gap:     geom.Size{W: 10, H: 20},
+			},
+			items: []GridItem{
+				{Column: 0, Row: 0},
+				{Column: 1, Row: 0},
+				{Column: 2, Row: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 50, 40), rect(60, 0, 45, 40), rect(115, 60, 135, 40)},
+		},
+		{
+			key: "auto tracks",
+			grid: Grid{
+				Columns: []Track{AutoTrack(), FractionTrack(1)},
+				Rows:    []Track{AutoTrack(), AutoTrack()},
+			},
+			items: []GridItem{
+				{Column: 0, Row: 0, Size: geom.Size{W: 30, H: 10}},
+				{Column: 0, Row: 1, Size: geom.Size{W: 40, H: 20}},
+				{Column: 1, Row: 0},
+			},
+			// The rows stretch to fill the container, sharing the free
+			// space equally.
+			rects: []geom.Rect{rect(0, 0, 40, 45), rect(0, 45, 40, 55), rect(40, 0, 210, 45)},
+		},
+		{
+			key: "minmax",
+			grid: Grid{
+				Columns: []Track{
+					{Min: TrackSize{TrackFixed, 50}, Max: TrackSize{TrackFixed, 100}},
+					FractionTrack(1),
+				},
+			},
+			items: []GridItem{
+				{Column: 0},
+				{Column: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 100, 100), rect(100, 0, 150, 100)},
+		},
+		{
+			key: "minmax without enough space",
+			grid: Grid{
+				Columns: []Track{
+					{Min: TrackSize{TrackFixed, 50}, Max: TrackSize{TrackFixed, 300}},
+					{Min: TrackSize{TrackFixed, 50}, Max: TrackSize{TrackFixed, 100}},
+				},
+			},
+			items: []GridItem{
+				{Column: 0},
+				{Column: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 150, 100), rect(150, 0, 100, 100)},
+		},
+		{
+			key: "fraction smaller than content",
+			grid: Grid{
+				Columns: []Track{FractionTrack(1), FractionTrack(1)},
+			},
+			items: []GridItem{
+				{Column: 0, Size: geom.Size{W: 200}},
+				{Column: 1},
+			},
+			rects: []geom.Rect{rect(0, 0, 200, 100), rect(200, 0, 50, 100)},
+		},
+		{
+			key: "spans",
+			grid: Grid{
+				Columns: []Track{AutoTrack(), AutoTrack(), FixedTrack(150)},
+				Rows:    []Track{FixedTrack(50), FixedTrack(50)},
+			},
+			items: []GridItem{
+				{Column: 0, Row: 0, Size: geom.Size{W: 20}},
+				{Column: 0, Row: 1, ColumnSpan: 2, Size: geom.Size{W: 100}},
+				{Column: 1, Row: 0, ColumnSpan: 2, RowSpan: 2},
+			},
+			rects: []geom.Rect{rect(0, 0, 60, 50), rect(0, 50, 100, 50), rect(60, 0, 190, 100)},
+		},
+		{
+			key: "implicit tracks",
+			grid: Grid{
+				Columns: []Track{FixedTrack(100)},
+				Rows:    []Track{FixedTrack(20)},
+			},
+			items: []GridItem{
+				{Column: 0, Row: 0},
+				{Column: 1, Row: 2, Size: geom.Size{W: 10, H: 30}},
+			},
+			// The implicit rows are auto tracks, which share the free space.
+			rects: []geom.Rect{rect(0, 0, 100, 20), rect(100, 45, 150, 55)},
+		},
+		{
+			key: "margins",
+			grid: Grid{
+				Columns: []Track{AutoTrack(), FractionTrack(1)},
+				Rows:    []Track{FixedTrack(100)},
+			},
+			items: []GridItem{
+				{Column: 0, Size: geom.Size{W: 30}, Margin: geom.Margin{Left: 5, Right: 5, Top: 10}},
+				{Column: 1, Margin: geom.MakeMargin(10)},
+			},
+			rects: []geom.Rect{rect(5, 10, 30, 90), rect(50, 10, 190, 80)},
+		},
+		{
+			key: "negative indexes",
+			grid: Grid{
+				Columns: []Track{FixedTrack(10)},
+				Rows:    []Track{FixedTrack(10)},
+			},
+			items: []GridItem{
+				{Column: -1, Row: -1},
+			},
+			rects: []geom.Rect{rect(0, 0, 10, 10)},
+		},
+	}
+
+	for _, test := range tests {
+		if rects := test.grid.Layout(container, test.items); !rectsNearlyEqual(rects, test.rects) {
+			t.Errorf("%s: %v != %v", test.key, rects, test.rects)
+		}
+	}
+}
+
+func TestGridLayoutOrigin(t *testing.T) {
+	g := Grid{
+		Columns: []Track{FractionTrack(1), FractionTrack(1)},
+		Rows:    []Track{FractionTrack(1)},
+	}
+
+	rects := g.Layout(geom.Rect{X: 110, Y: 120, W: -100, H: -100}, []GridItem{{Column: 1}})
+	expected := []geom.Rect{rect(60, 20, 50, 100)}
+
+	if !rectsNearlyEqual(rects, expected) {
+		t.Errorf("%v != %v", rects, expected)
+	}
+}
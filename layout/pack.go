@@ -0,0 +1,480 @@
+package layout
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-vu/geom"
+)
+
+// PackHeuristic is an enumeration of the algorithms that a Packer can use to
+// choose where rectangles are placed.
+type PackHeuristic int
+
+const (
+	// PackMaxRects maintains the list of maximal free rectangles of the
+	// container, and places each rectangle in the free area which leaves the
+	// shortest side leftover. It produces the densest results but is slower
+	// than PackSkyline.
+	PackMaxRects PackHeuristic = iota
+
+	// PackSkyline maintains the upper envelope of the placed rectangles, and
+	// places each rectangle at the position where its bottom edge is the
+	// lowest. Space below the skyline is never reused.
+	PackSkyline
+)
+
+// The String method returns a human-readable representation of the value.
+func (h PackHeuristic) String() string {
+	switch h {
+	case PackMaxRects:
+		return "max-rects"
+	case PackSkyline:
+		return "skyline"
+	default:
+		return "unknown"
+	}
+}
+
+// A Packer places rectangles without overlaps in a container, which is
+// typically used to build texture atlases of glyphs or icons.
+//
+// The container has its top-left corner at the origin. When MaxSize is larger
+// than Size the container grows, doubling one of its dimensions at a time,
+// until rectangles which don't fit can be placed.
+//
+// Configuration fields are read on the first insertion and when Reset is
+// called, changing them in between has no effect.
+type Packer struct {
+	// The algorithm used to choose the positions of rectangles.
+	Heuristic PackHeuristic
+
+	// The initial size of the container.
+	Size geom.Size
+
+	// The size up to which the container can grow, the container doesn't
+	// grow when a dimension is not larger than the one of Size.
+	MaxSize geom.Size
+
+	// The space reserved around each rectangle, padding is also kept between
+	// rectangles and the edges of the container.
+	Padding geom.Margin
+
+	init    bool
+	size    geom.Size
+	rects   []geom.Rect
+	outer   []geom.Rect
+	free    []geom.Rect
+	skyline []skylineNode
+}
+
+// skylineNode is a horizontal segment of the skyline, starting at x with a
+// width of w, at the height y.
+type skylineNode struct {
+	x float64
+	y float64
+	w float64
+}
+
+// Insert places a rectangle of the given size in the container, and returns its
+// position. The method returns false if the rectangle couldn't be placed, even
+// after growing the container.
+//
+// Sizes with a zero or negative dimension have nothing to pack and are always
+// rejected, whatever the heuristic and the space left in the container are.
+func (p *Packer) Insert(s geom.Size) (geom.Rect, bool) {
+	if !p.init {
+		p.Reset()
+	}
+
+	if s.W <= 0 || s.H <= 0 {
+		return geom.Rect{}, false
+	}
+
+	w := s.W + p.Padding.Width()
+	h := s.H + p.Padding.Height()
+	pos, ok := p.find(w, h)
+
+	if !ok {
+		if pos, ok = p.grow(w, h); !ok {
+			return geom.Rect{}, false
+		}
+	}
+
+	r := geom.Rect{X: pos.X + p.Padding.Left, Y: pos.Y + p.Padding.Top, W: s.W, H: s.H}
+	o := geom.Rect{X: pos.X, Y: pos.Y, W: w, H: h}
+	p.rects = append(p.rects, r)
+	p.outer = append(p.outer, o)
+	p.place(o)
+	return r, true
+}
+
+// Pack places a list of rectangles in the container, and returns their
+// positions in the same order as the sizes.
+//
+// The rectangles are inserted from the largest to the smallest, which usually
+// gives denser results than inserting them one by one. The method returns
+// false if some rectangles couldn't be placed, their positions are then
+// zero-value rectangles.
+func (p *Packer) Pack(sizes []geom.Size) ([]geom.Rect, bool) {
+	order := make([]int, len(sizes))
+
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i int, j int) bool {
+		s1, s2 := sizes[order[i]], sizes[order[j]]
+		m1, m2 := math.Max(s1.W, s1.H), math.Max(s2.W, s2.H)
+
+		if m1 != m2 {
+			return m1 > m2
+		}
+
+		return s1.Area() > s2.Area()
+	})
+
+	rects := make([]geom.Rect, len(sizes))
+	all := true
+
+	for _, i := range order {
+		r, ok := p.Insert(sizes[i])
+		rects[i] = r
+		all = all && ok
+	}
+
+	return rects, all
+}
+
+// Remove releases the area occupied by a rectangle that was returned by Insert
+// or Pack, so it can be reused by rectangles inserted later. The method returns
+// false if the rectangle wasn't found in the packer.
+func (p *Packer) Remove(r geom.Rect) bool {
+	for i, x := range p.rects {
+		if x == r {
+			p.rects = append(p.rects[:i], p.rects[i+1:]...)
+			p.outer = append(p.outer[:i], p.outer[i+1:]...)
+			p.rebuild()
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds returns the rectangle of the container, which may be larger than Size
+// when the packer has grown.
+func (p *Packer) Bounds() geom.Rect {
+	if !p.init {
+		return geom.Rect{W: p.Size.W, H: p.Size.H}
+	}
+	return geom.Rect{W: p.size.W, H: p.size.H}
+}
+
+// Rects returns the list of rectangles placed in the container, in the order
+// they were inserted. The returned slice is owned by the packer and is only
+// valid until the next call to Insert, Pack, Remove or Reset.
+func (p *Packer) Rects() []geom.Rect {
+	return p.rects
+}
+
+// Occupancy returns the fraction of the area of the container covered by the
+// placed rectangles, padding excluded.
+func (p *Packer) Occupancy() float64 {
+	area := p.Bounds().Area()
+
+	if area == 0 {
+		return 0
+	}
+
+	used := 0.0
+
+	for _, r := range p.rects {
+		used += r.Area()
+	}
+
+	return used / area
+}
+
+// Reset removes all rectangles from the packer and restores the container to
+// its initial size.
+func (p *Packer) Reset() {
+	p.init = true
+	p.size = geom.Size{W: math.Max(p.Size.W, 0), H: math.Max(p.Size.H, 0)}
+	p.rects = p.rects[:0]
+	p.outer = p.outer[:0]
+	p.rebuild()
+}
+
+// grow enlarges the container until a rectangle of size w x h can be placed,
+// and returns its position. The container is restored to its previous size if
+// the rectangle doesn't fit even at the maximum size.
+func (p *Packer) grow(w float64, h float64) (geom.Point, bool) {
+	size := p.size
+	maxW := math.Max(p.MaxSize.W, size.W)
+	maxH := math.Max(p.MaxSize.H, size.H)
+
+	for {
+		growW := p.size.W < maxW
+		growH := p.size.H < maxH
+
+		switch {
+		case !growW && !growH:
+			p.size = size
+			p.rebuild()
+			return geom.Point{}, false
+		case growW && (w > p.size.W || !growH || (p.size.W <= p.size.H && h <= p.size.H)):
+			p.size.W = growDimension(p.size.W, w, maxW)
+		default:
+			p.size.H = growDimension(p.size.H, h, maxH)
+		}
+
+		p.rebuild()
+
+		if pos, ok := p.find(w, h); ok {
+			return pos, true
+		}
+	}
+}
+
+// growDimension returns the next size of a dimension of the container, which
+// doubles or becomes large enough for the requested size. The result is always
+// larger than size when size is less than max, so growing the container makes
+// progress even when it starts with a zero dimension.
+func growDimension(size float64, request float64, max float64) float64 {
+	if next := math.Min(math.Max(2*size, request), max); next > size {
+		return next
+	}
+	return max
+}
+
+// rebuild recomputes the free areas of the container from the list of placed
+// rectangles.
+func (p *Packer) rebuild() {
+	p.free = p.free[:0]
+	p.skyline = p.skyline[:0]
+
+	switch p.Heuristic {
+	case PackSkyline:
+		p.rebuildSkyline()
+	default:
+		if !p.size.Empty() {
+			p.free = append(p.free, geom.Rect{W: p.size.W, H: p.size.H})
+		}
+
+		for _, o := range p.outer {
+			p.place(o)
+		}
+	}
+}
+
+func (p *Packer) find(w float64, h float64) (geom.Point, bool) {
+	if p.Heuristic == PackSkyline {
+		return p.findSkyline(w, h)
+	}
+	return p.findMaxRects(w, h)
+}
+
+func (p *Packer) place(r geom.Rect) {
+	if p.Heuristic == PackSkyline {
+		p.placeSkyline(r)
+	} else {
+		p.placeMaxRects(r)
+	}
+}
+
+// findMaxRects returns the position of the free rectangle where a rectangle of
+// size w x h leaves the shortest side leftover, ties are broken using the long
+// side leftover.
+func (p *Packer) findMaxRects(w float64, h float64) (geom.Point, bool) {
+	var best geom.Point
+	found := false
+	bestShort, bestLong := 0.0, 0.0
+
+	for _, f := range p.free {
+		if w > f.W || h > f.H {
+			continue
+		}
+
+		dw, dh := f.W-w, f.H-h
+		short, long := math.Min(dw, dh), math.Max(dw, dh)
+
+		if !found || short < bestShort || (short == bestShort && long < bestLong) {
+			best, bestShort, bestLong, found = geom.Point{X: f.X, Y: f.Y}, short, long, true
+		}
+	}
+
+	return best, found
+}
+
+// placeMaxRects splits the free rectangles that overlap with r into the maximal
+// rectangles of the remaining free area, then removes the free rectangles that
+// are contained in others.
+func (p *Packer) placeMaxRects(r geom.Rect) {
+	if r.Empty() {
+		return
+	}
+
+	n := len(p.free)
+
+	for i := 0; i < n; {
+		f := p.free[i]
+
+		if r.X >= f.X+f.W || r.X+r.W <= f.X || r.Y >= f.Y+f.H || r.Y+r.H <= f.Y {
+			i++
+			continue
+		}
+
+		if r.X > f.X {
+			p.free = append(p.free, geom.Rect{X: f.X, Y: f.Y, W: r.X - f.X, H: f.H})
+		}
+
+		if r.X+r.W < f.X+f.W {
+			p.free = append(p.free, geom.Rect{X: r.X + r.W, Y: f.Y, W: f.X + f.W - (r.X + r.W), H: f.H})
+		}
+
+		if r.Y > f.Y {
+			p.free = append(p.free, geom.Rect{X: f.X, Y: f.Y, W: f.W, H: r.Y - f.Y})
+		}
+
+		if r.Y+r.H < f.Y+f.H {
+			p.free = append(p.free, geom.Rect{X: f.X, Y: r.Y + r.H, W: f.W, H: f.Y + f.H - (r.Y + r.H)})
+		}
+
+		p.free = append(p.free[:i], p.free[i+1:]...)
+		n--
+	}
+
+	for i := 0; i < len(p.free); i++ {
+		for j := i + 1; j < len(p.free); j++ {
+			if p.free[j].ContainsRect(p.free[i]) {
+				p.free = append(p.free[:i], p.free[i+1:]...)
+				i--
+				break
+			}
+
+			if p.free[i].ContainsRect(p.free[j]) {
+				p.free = append(p.free[:j], p.free[j+1:]...)
+				j--
+			}
+		}
+	}
+}
+
+// findSkyline returns the position where a rectangle of size w x h has the
+// lowest bottom edge, ties are broken by choosing the left-most position.
+func (p *Packer) findSkyline(w float64, h float64) (geom.Point, bool) {
+	var best geom.Point
+	found := false
+	bestBottom := 0.0
+
+	for i, n := range p.skyline {
+		y, ok := p.fitSkyline(i, w, h)
+
+		if ok && (!found || y+h < bestBottom) {
+			best, bestBottom, found = geom.Point{X: n.x, Y: y}, y+h, true
+		}
+	}
+
+	return best, found
+}
+
+// fitSkyline returns the height at which a rectangle of size w x h starting at
+// the i-th node of the skyline would be placed.
+func (p *Packer) fitSkyline(i int, w float64, h float64) (float64, bool) {
+	if p.skyline[i].x+w > p.size.W {
+		return 0, false
+	}
+
+	y := p.skyline[i].y
+
+	for left := w; left > 0; i++ {
+		if i == len(p.skyline) {
+			return 0, false
+		}
+
+		y = math.Max(y, p.skyline[i].y)
+		left -= p.skyline[i].w
+	}
+
+	return y, y+h <= p.size.H
+}
+
+// placeSkyline raises the skyline to the bottom edge of r, which must have been
+// placed at a position returned by findSkyline.
+func (p *Packer) placeSkyline(r geom.Rect) {
+	if r.W == 0 {
+		return
+	}
+
+	i := sort.Search(len(p.skyline), func(i int) bool { return p.skyline[i].x >= r.X })
+	p.skyline = append(p.skyline, skylineNode{})
+	copy(p.skyline[i+1:], p.skyline[i:])
+	p.skyline[i] = skylineNode{x: r.X, y: r.Y + r.H, w: r.W}
+
+	for j := i + 1; j < len(p.skyline); {
+		end := p.skyline[i].x + p.skyline[i].w
+		n := &p.skyline[j]
+
+		if n.x >= end {
+			break
+		}
+
+		n.w -= end - n.x
+		n.x = end
+
+		if n.w > 0 {
+			break
+		}
+
+		p.skyline = append(p.skyline[:j], p.skyline[j+1:]...)
+	}
+
+	p.mergeSkyline()
+}
+
+// rebuildSkyline computes the skyline as the upper envelope of the placed
+// rectangles.
+func (p *Packer) rebuildSkyline() {
+	if p.size.W <= 0 {
+		return
+	}
+
+	xs := []float64{0, p.size.W}
+
+	for _, o := range p.outer {
+		xs = append(xs, o.X, o.X+o.W)
+	}
+
+	sort.Float64s(xs)
+
+	for i := 1; i < len(xs); i++ {
+		x0, x1 := xs[i-1], xs[i]
+
+		if x0 >= x1 || x0 < 0 || x1 > p.size.W {
+			continue
+		}
+
+		y := 0.0
+
+		for _, o := range p.outer {
+			if o.X < x1 && o.X+o.W > x0 {
+				y = math.Max(y, o.Y+o.H)
+			}
+		}
+
+		p.skyline = append(p.skyline, skylineNode{x: x0, y: y, w: x1 - x0})
+	}
+
+	p.mergeSkyline()
+}
+
+func (p *Packer) mergeSkyline() {
+	for i := 0; i+1 < len(p.skyline); {
+		if p.skyline[i].y == p.skyline[i+1].y {
+			p.skyline[i].w += p.skyline[i+1].w
+			p.skyline = append(p.skyline[:i+1], p.skyline[i+2:]...)
+		} else {
+			i++
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/go-vu/geom"
+)
+
+var heuristics = []PackHeuristic{PackMaxRects, PackSkyline}
+
+// checkPacking verifies that the rectangles don't overlap, including their
+// padding, and that they are all inside the container.
+func checkPacking(t *testing.T, p *Packer) {
+	bounds := p.Bounds()
+	rects := p.Rects()
+
+	for i, r := range rects {
+		o := p.Padding.GrowRect(r)
+
+		if !bounds.ContainsRect(o) {
+			t.Errorf("%s: %s is outside of %s", p.Heuristic, o, bounds)
+		}
+
+		for _, r1 := range rects[i+1:] {
+			if o1 := p.Padding.GrowRect(r1); !o.Intersect(o1).Empty() {
+				t.Errorf("%s: %s and %s overlap", p.Heuristic, o, o1)
+			}
+		}
+	}
+}
+
+func TestPackHeuristicString(t *testing.T) {
+	tests := []struct {
+		heuristic PackHeuristic
+		str       string
+	}{
+		{PackMaxRects, "max-rects"},
+		{PackSkyline, "skyline"},
+		{PackHeuristic(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		if s := test.heuristic.String(); s != test.str {
+			t.Errorf("%d: %s != %s", test.heuristic, s, test.str)
+		}
+	}
+}
+
+func TestPackerInsert(t *testing.T) {
+	for _, h := range heuristics {
+		p := &Packer{Heuristic: h, Size: geom.Size{W: 20, H: 20}}
+
+		for i := 0; i != 4; i++ {
+			r, ok := p.Insert(geom.Size{W: 10, H: 10})
+
+			if !ok {
+				t.Errorf("%s: rectangle %d wasn't inserted", h, i)
+			}
+
+			if r.Size() != (geom.Size{W: 10, H: 10}) {
+				t.Errorf("%s: invalid size of rectangle %d: %s", h, i, r.Size())
+			}
+		}
+
+		if _, ok := p.Insert(geom.Size{W: 1, H: 1}); ok {
+			t.Errorf("%s: rectangle inserted in a full container", h)
+		}
+
+		if _, ok := p.Insert(geom.Size{W: -1, H: 1}); ok {
+			t.Errorf("%s: rectangle with a negative size inserted", h)
+		}
+
+		if n := len(p.Rects()); n != 4 {
+			t.Errorf("%s: invalid number of rectangles: %d", h, n)
+		}
+
+		if o := p.Occupancy(); o != 1 {
+			t.Errorf("%s: invalid occupancy: %g", h, o)
+		}
+
+		checkPacking(t, p)
+	}
+}
+
+func TestPackerPadding(t *testing.T) {
+	for _, h := range heuristics {
+		p := &Packer{Heuristic: h, Size: geom.Size{W: 24, H: 12}, Padding: geom.MakeMargin(1)}
+
+		r1, ok1 := p.Insert(geom.Size{W: 10, H: 10})
+		r2, ok2 := p.Insert(geom.Size{W: 10, H: 10})
+
+		if !ok1 || !ok2 {
+			t.Errorf("%s: rectangles weren't inserted", h)
+		}
+
+		if r1 != rect(1, 1, 10, 10) || r2 != rect(13, 1, 10, 10) {
+			t.Errorf("%s: invalid rectangles: %s %s", h, r1, r2)
+		}
+
+		if _, ok := p.Insert(geom.Size{W: 1, H: 1}); ok {
+			t.Errorf("%s: padding of the rectangles wasn't reserved", h)
+		}
+	}
+}
+
+func TestPackerGrow(t *testing.T) {
+	for _, h := range heuristics {
+		p := &Packer{Heuristic: h, Size: geom.Size{W: 16, H: 16}, MaxSize: geom.Size{W: 64, H: 64}}
+
+		for i := 0; i != 4; i++ {
+			if _, ok := p.Insert(geom.Size{W: 16, H: 16}); !ok {
+				t.Errorf("%s: rectangle %d wasn't inserted", h, i)
+			}
+		}
+
+		if b := p.Bounds(); b != rect(0, 0, 32, 32) {
+			t.Errorf("%s: invalid bounds after growing: %s", h, b)
+		}
+
+		if _, ok := p.Insert(geom.Size{W: 100, H: 1}); ok {
+			t.Errorf("%s: rectangle larger than the maximum size inserted", h)
+		}
+
+		if b := p.Bounds(); b != rect(0, 0, 32, 32) {
+			t.Errorf("%s: bounds not restored after a failed insertion: %s", h, b)
+		}
+
+		if _, ok := p.Insert(geom.Size{W: 64, H: 8}); !ok {
+			t.Errorf("%s: wide rectangle wasn't inserted", h)
+		}
+
+		if b := p.Bounds(); b != rect(0, 0, 64, 64) {
+			t.Errorf("%s: invalid bounds after growing: %s", h, b)
+		}
+
+		checkPacking(t, p)
+		p.Reset()
+
+		if b := p.Bounds(); b != rect(0, 0, 16, 16) || len(p.Rects()) != 0 {
+			t.Errorf("%s: packer not reset: %s %v", h, b, p.Rects())
+		}
+	}
+}
+
+func TestPackerGrowFromZero(t *testing.T) {
+	sizes := []geom.Size{{W: 5, H: 5}, {W: 1, H: 20}, {W: 20, H: 1}}
+
+	for _, h := range heuristics {
+		for _, s := range sizes {
+			p := &Packer{Heuristic: h, MaxSize: geom.Size{W: 100, H: 100}}
+
+			if _, ok := p.Insert(s); !ok {
+				t.Errorf("%s: rectangle of size %s wasn't inserted", h, s)
+			}
+
+			if b := p.Bounds(); b.W > 100 || b.H > 100 {
+				t.Errorf("%s: bounds larger than the maximum size: %s", h, b)
+			}
+
+			if _, ok := p.Insert(geom.Size{W: 101, H: 1}); ok {
+				t.Errorf("%s: rectangle larger than the maximum size inserted", h)
+			}
+
+			checkPacking(t, p)
+		}
+	}
+}
+
+func TestPackerEmptySize(t *testing.T) {
+	sizes := []geom.Size{{W: 0, H: 0}, {W: 5, H: 0}, {W: 0, H: 5}, {W: -1, H: 5}}
+
+	for _, h := range heuristics {
+		empty := &Packer{Heuristic: h, Size: geom.Size{W: 10, H: 10}}
+		full := &Packer{Heuristic: h, Size: geom.Size{W: 10, H: 10}}
+		full.Insert(geom.Size{W: 10, H: 10})
+
+		for _, p := range []*Packer{empty, full} {
+			n := len(p.Rects())
+
+			for _, s := range sizes {
+				if r, ok := p.Insert(s); ok {
+					t.Errorf("%s: rectangle of size %s inserted at %s", h, s, r)
+				}
+			}
+
+			if len(p.Rects()) != n {
+				t.Errorf("%s: empty rectangles were added to the packer: %v", h, p.Rects())
+			}
+		}
+	}
+}
+
+func TestPackerRemove(t *testing.T) {
+	for _, h := range heuristics {
+		p := &Packer{Heuristic: h, Size: geom.Size{W: 20, H: 20}}
+		rects, ok := p.Pack([]geom.Size{{W: 10, H: 10}, {W: 10, H: 10}, {W: 10, H: 10}, {W: 10, H: 10}})
+
+		if !ok {
+			t.Errorf("%s: rectangles weren't packed", h)
+			continue
+		}
+
+		if p.Remove(rect(1, 2, 3, 4)) {
+			t.Errorf("%s: unknown rectangle removed", h)
+		}
+
+		// The last rectangle is on the top of the skyline so its area can be
+		// reused by both heuristics.
+		if !p.Remove(rects[3]) {
+			t.Errorf("%s: rectangle wasn't removed", h)
+		}
+
+		if r, ok := p.Insert(geom.Size{W: 10, H: 10}); !ok || r != rects[3] {
+			t.Errorf("%s: area of the removed rectangle wasn't reused: %s", h, r)
+		}
+
+		checkPacking(t, p)
+	}
+}
+
+func TestPackerPack(t *testing.T) {
+	sizes := make([]geom.Size, 0, 100)
+
+	for i := 0; i != 100; i++ {
+		sizes = append(sizes, geom.Size{W: float64(1 + (i*7)%13), H: float64(1 + (i*11)%17)})
+	}
+
+	for _, h := range heuristics {
+		p := &Packer{
+			Heuristic: h,
+			Size:      geom.Size{W: 32, H: 32},
+			MaxSize:   geom.Size{W: 256, H: 256},
+			Padding:   geom.Margin{Right: 1, Bottom: 1},
+		}
+		rects, ok := p.Pack(sizes)
+
+		if !ok {
+			t.Errorf("%s: rectangles weren't packed", h)
+		}
+
+		for i, r := range rects {
+			if r.Size() != sizes[i] {
+				t.Errorf("%s: rectangle %d has an invalid size: %s != %s", h, i, r.Size(), sizes[i])
+			}
+		}
+
+		// The rectangles cover 6240 square units, 7928 with padding, which
+		// fit in a 128x128 container.
+		if b := p.Bounds(); b != rect(0, 0, 128, 128) {
+			t.Errorf("%s: invalid bounds: %s", h, b)
+		}
+
+		if o := p.Occupancy(); o != 6240.0/(128*128) {
+			t.Errorf("%s: invalid occupancy: %g", h, o)
+		}
+
+		checkPacking(t, p)
+	}
+}
+
+func TestPackerZeroValue(t *testing.T) {
+	var p Packer
+
+	if _, ok := p.Insert(geom.Size{W: 1, H: 1}); ok {
+		t.Error("rectangle inserted in an empty container")
+	}
+
+	if o := p.Occupancy(); o != 0 {
+		t.Error("invalid occupancy of an empty container:", o)
+	}
+}
+
+func BenchmarkPacker(b *testing.B) {
+	sizes := make([]geom.Size, 0, 500)
+
+	for i := 0; i != 500; i++ {
+		sizes = append(sizes, geom.Size{W: float64(4 + (i*7)%29), H: float64(4 + (i*11)%31)})
+	}
+
+	for _, h := range heuristics {
+		b.Run(h.String(), func(b *testing.B) {
+			for i := 0; i != b.N; i++ {
+				p := Packer{Heuristic: h, Size: geom.Size{W: 256, H: 256}, MaxSize: geom.Size{W: 1024, H: 1024}}
+				p.Pack(sizes)
+			}
+		})
+	}
+}
@@ -0,0 +1,398 @@
+package layout
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-vu/geom"
+)
+
+// TreemapAlgorithm is an enumeration of the algorithms that a Treemap can use
+// to divide rectangles between items.
+type TreemapAlgorithm int
+
+const (
+	// TreemapSquarified places items from the heaviest to the lightest in rows
+	// along the shortest side of the remaining area, producing rectangles
+	// with aspect ratios close to one. The order of the items is not
+	// preserved.
+	TreemapSquarified TreemapAlgorithm = iota
+
+	// TreemapSliceAndDice divides the area in a single row of items,
+	// alternating between horizontal and vertical rows at each level of the
+	// hierarchy. The order of the items is preserved but rectangles can be
+	// very thin.
+	TreemapSliceAndDice
+
+	// TreemapStrip places items in horizontal strips stacked from top to
+	// bottom, starting a new strip when adding an item would make the average
+	// aspect ratio of the current one worse. The order of the items is
+	// preserved.
+	TreemapStrip
+)
+
+// The String method returns a human-readable representation of the value.
+func (a TreemapAlgorithm) String() string {
+	switch a {
+	case TreemapSquarified:
+		return "squarified"
+	case TreemapSliceAndDice:
+		return "slice-and-dice"
+	case TreemapStrip:
+		return "strip"
+	default:
+		return "unknown"
+	}
+}
+
+// A TreemapItem describes a weighted item laid out by a Treemap.
+type TreemapItem struct {
+	// The weight of the item, the area of the rectangle of an item is
+	// proportional to its weight. Negative weights are treated as zero.
+	//
+	// The weight of an item which has children is the sum of the weights of
+	// its children, and the value of this field is ignored.
+	Weight float64
+
+	// The children of the item, which are laid out in the rectangle of their
+	// parent.
+	Children []TreemapItem
+}
+
+// The Treemap type is a layout which divides a container in rectangles with
+// areas proportional to the weights of items, the children of items are
+// recursively laid out in the rectangles of their parents.
+type Treemap struct {
+	// The algorithm used to divide rectangles between items.
+	Algorithm TreemapAlgorithm
+
+	// The space between the rectangle of an item and the rectangles of its
+	// children.
+	Padding geom.Margin
+}
+
+// Layout computes the rectangles of the items placed in the container given as
+// argument, and of all their descendants.
+//
+// The rectangles are returned in depth-first order, the rectangle of each item
+// is followed by the rectangles of its children, then by the rectangle of its
+// next sibling. Items with a zero weight have rectangles with a zero width and
+// height, whatever the algorithm is.
+func (t Treemap) Layout(container geom.Rect, items []TreemapItem) []geom.Rect {
+	rects := make([]geom.Rect, 0, countTreemapItems(items))
+	return t.layout(rects, container.Abs(), items, 0)
+}
+
+func (t Treemap) layout(rects []geom.Rect, container geom.Rect, items []TreemapItem, depth int) []geom.Rect {
+	if len(items) == 0 {
+		return rects
+	}
+
+	weights := make([]float64, len(items))
+	level := make([]geom.Rect, len(items))
+
+	for i, item := range items {
+		weights[i] = item.weight()
+	}
+
+	switch t.Algorithm {
+	case TreemapSliceAndDice:
+		sliceAndDice(level, container, weights, depth%2 != 0)
+	case TreemapStrip:
+		strip(level, container, weights)
+	default:
+		squarify(level, container, weights)
+	}
+
+	for i, item := range items {
+		rects = append(rects, level[i])
+		rects = t.layout(rects, t.Padding.ShrinkRect(level[i]), item.Children, depth+1)
+	}
+
+	return rects
+}
+
+func (item TreemapItem) weight() float64 {
+	if len(item.Children) == 0 {
+		return math.Max(item.Weight, 0)
+	}
+
+	w := 0.0
+
+	for _, c := range item.Children {
+		w += c.weight()
+	}
+
+	return w
+}
+
+func countTreemapItems(items []TreemapItem) int {
+	n := len(items)
+
+	for _, item := range items {
+		n += countTreemapItems(item.Children)
+	}
+
+	return n
+}
+
+// treemapAreas converts the weights to the areas of the rectangles of the
+// items in the container.
+func treemapAreas(container geom.Rect, weights []float64) []float64 {
+	areas := make([]float64, len(weights))
+	total := 0.0
+
+	for _, w := range weights {
+		total += w
+	}
+
+	if total == 0 {
+		return areas
+	}
+
+	for i, w := range weights {
+		areas[i] = w * container.Area() / total
+	}
+
+	return areas
+}
+
+// sliceAndDice divides the container in a single row of rectangles, which is
+// vertical when the vertical argument is true.
+func sliceAndDice(rects []geom.Rect, container geom.Rect, weights []float64, vertical bool) {
+	total := 0.0
+
+	for _, w := range weights {
+		total += w
+	}
+
+	pos := 0.0
+
+	for i, w := range weights {
+		f := 0.0
+
+		if total != 0 {
+			f = w / total
+		}
+
+		if f == 0 {
+			rects[i] = geom.Rect{X: container.X, Y: container.Y}
+
+			if vertical {
+				rects[i].Y += pos
+			} else {
+				rects[i].X += pos
+			}
+
+			continue
+		}
+
+		if vertical {
+			rects[i] = geom.Rect{X: container.X, Y: container.Y + pos, W: container.W, H: container.H * f}
+			pos += rects[i].H
+		} else {
+			rects[i] = geom.Rect{X: container.X + pos, Y: container.Y, W: container.W * f, H: container.H}
+			pos += rects[i].W
+		}
+	}
+}
+
+// squarify implements the squarified treemap algorithm described by Bruls,
+// Huizing and van Wijk.
+func squarify(rects []geom.Rect, container geom.Rect, weights []float64) {
+	areas := treemapAreas(container, weights)
+	order := make([]int, len(areas))
+
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i int, j int) bool { return areas[order[i]] > areas[order[j]] })
+
+	// Items with a zero area are at the end of the order, they are excluded
+	// from rows and placed at the origin of the container.
+	n := len(order)
+
+	for n != 0 && areas[order[n-1]] == 0 {
+		n--
+		rects[order[n]] = geom.Rect{X: container.X, Y: container.Y}
+	}
+
+	row := make([]float64, 0, n)
+	remain := container
+
+	for start := 0; start < n; {
+		side := math.Min(remain.W, remain.H)
+		row = append(row[:0], areas[order[start]])
+		worst := worstAspectRatio(row, side)
+		end := start + 1
+
+		for ; end < n; end++ {
+			w := worstAspectRatio(append(row, areas[order[end]]), side)
+
+			if w > worst {
+				break
+			}
+
+			row, worst = append(row, areas[order[end]]), w
+		}
+
+		remain = layoutTreemapRow(rects, order[start:end], row, remain)
+		start = end
+	}
+}
+
+// worstAspectRatio returns the largest aspect ratio of the rectangles of a row
+// with the given areas, laid out along a side of the given length.
+func worstAspectRatio(row []float64, side float64) float64 {
+	sum, min, max := 0.0, math.Inf(1), 0.0
+
+	for _, a := range row {
+		sum += a
+		min = math.Min(min, a)
+		max = math.Max(max, a)
+	}
+
+	if side == 0 || sum == 0 {
+		return math.Inf(1)
+	}
+
+	s2, w2 := sum*sum, side*side
+	return math.Max(w2*max/s2, s2/(w2*min))
+}
+
+// layoutTreemapRow places a row of rectangles along the shortest side of the
+// remaining area, and returns the area that is left after the row.
+func layoutTreemapRow(rects []geom.Rect, indexes []int, row []float64, remain geom.Rect) geom.Rect {
+	sum := 0.0
+
+	for _, a := range row {
+		sum += a
+	}
+
+	if remain.W >= remain.H {
+		w := 0.0
+
+		if remain.H != 0 {
+			w = math.Min(sum/remain.H, remain.W)
+		}
+
+		y := remain.Y
+
+		for k, i := range indexes {
+			h := 0.0
+
+			if w != 0 {
+				h = row[k] / w
+			}
+
+			rects[i] = geom.Rect{X: remain.X, Y: y, W: w, H: h}
+			y += h
+		}
+
+		remain.X += w
+		remain.W -= w
+	} else {
+		h := 0.0
+
+		if remain.W != 0 {
+			h = math.Min(sum/remain.W, remain.H)
+		}
+
+		x := remain.X
+
+		for k, i := range indexes {
+			w := 0.0
+
+			if h != 0 {
+				w = row[k] / h
+			}
+
+			rects[i] = geom.Rect{X: x, Y: remain.Y, W: w, H: h}
+			x += w
+		}
+
+		remain.Y += h
+		remain.H -= h
+	}
+
+	return remain
+}
+
+// strip implements the strip treemap algorithm described by Bederson, Shneiderman
+// and Wattenberg.
+func strip(rects []geom.Rect, container geom.Rect, weights []float64) {
+	areas := treemapAreas(container, weights)
+	y := container.Y
+	start := 0
+
+	for start < len(areas) {
+		end := start + 1
+		ratio := averageAspectRatio(areas[start:end], container.W)
+
+		for ; end < len(areas); end++ {
+			r := averageAspectRatio(areas[start:end+1], container.W)
+
+			if r > ratio {
+				break
+			}
+
+			ratio = r
+		}
+
+		h := 0.0
+
+		for _, a := range areas[start:end] {
+			h += a
+		}
+
+		if container.W != 0 {
+			h /= container.W
+		}
+
+		x := container.X
+
+		for i := start; i < end; i++ {
+			if areas[i] == 0 {
+				rects[i] = geom.Rect{X: x, Y: y}
+				continue
+			}
+
+			w := areas[i] / h
+			rects[i] = geom.Rect{X: x, Y: y, W: w, H: h}
+			x += w
+		}
+
+		y += h
+		start = end
+	}
+}
+
+// averageAspectRatio returns the average aspect ratio of the rectangles of a
+// horizontal strip with the given areas and width, rectangles with a zero area
+// are ignored.
+func averageAspectRatio(strip []float64, width float64) float64 {
+	sum := 0.0
+
+	for _, a := range strip {
+		sum += a
+	}
+
+	if sum == 0 || width == 0 {
+		return math.Inf(1)
+	}
+
+	h := sum / width
+	ratio := 0.0
+	n := 0
+
+	for _, a := range strip {
+		if a != 0 {
+			w := a / h
+			ratio += math.Max(w/h, h/w)
+			n++
+		}
+	}
+
+	return ratio / float64(n)
+}
@@ -0,0 +1,188 @@
+package layout
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-vu/geom"
+)
+
+func TestTreemapAlgorithmString(t *testing.T) {
+	tests := []struct {
+		algorithm TreemapAlgorithm
+		str       string
+	}{
+		{TreemapSquarified, "squarified"},
+		{TreemapSliceAndDice, "slice-and-dice"},
+		{TreemapStrip, "strip"},
+		{TreemapAlgorithm(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		if s := test.algorithm.String(); s != test.str {
+			t.Errorf("%d: %s != %s", test.algorithm, s, test.str)
+		}
+	}
+}
+
+func TestTreemapLayout(t *testing.T) {
+	// The example used in the paper describing the squarified algorithm.
+	weights := []TreemapItem{{Weight: 6}, {Weight: 6}, {Weight: 4}, {Weight: 3}, {Weight: 2}, {Weight: 2}, {Weight: 1}}
+
+	tests := []struct {
+		key       string
+		treemap   Treemap
+		container geom.Rect
+		items     []TreemapItem
+		rects     []geom.Rect
+	}{
+		{
+			key:       "no items",
+			treemap:   Treemap{},
+			container: rect(0, 0, 6, 4),
+			items:     nil,
+			rects:     []geom.Rect{},
+		},
+		{
+			key:       "squarified",
+			treemap:   Treemap{Algorithm: TreemapSquarified},
+			container: rect(0, 0, 6, 4),
+			items:     weights,
+			rects: []geom.Rect{
+				rect(0, 0, 3, 2),
+				rect(0, 2, 3, 2),
+				rect(3, 0, 12.0/7, 7.0/3),
+				rect(3+12.0/7, 0, 9.0/7, 7.0/3),
+				rect(3, 7.0/3, 1.2, 5.0/3),
+				rect(4.2, 7.0/3, 1.2, 5.0/3),
+				rect(5.4, 7.0/3, 0.6, 5.0/3),
+			},
+		},
+		{
+			key:       "squarified order",
+			treemap:   Treemap{Algorithm: TreemapSquarified},
+			container: rect(10, 20, 4, 2),
+			items:     []TreemapItem{{Weight: 1}, {Weight: 0}, {Weight: 3}},
+			rects:     []geom.Rect{rect(10+3, 20, 1, 2), rect(10, 20, 0, 0), rect(10, 20, 3, 2)},
+		},
+		{
+			key:       "slice and dice",
+			treemap:   Treemap{Algorithm: TreemapSliceAndDice},
+			container: rect(0, 0, 100, 50),
+			items: []TreemapItem{
+				{Weight: 1},
+				{Children: []TreemapItem{{Weight: 1}, {Weight: 2}}},
+			},
+			rects: []geom.Rect{
+				rect(0, 0, 25, 50),
+				rect(25, 0, 75, 50),
+				rect(25, 0, 75, 50.0/3),
+				rect(25, 50.0/3, 75, 100.0/3),
+			},
+		},
+		{
+			key:       "strip",
+			treemap:   Treemap{Algorithm: TreemapStrip},
+			container: rect(0, 0, 6, 4),
+			items:     weights,
+			rects: []geom.Rect{
+				rect(0, 0, 2.25, 8.0/3),
+				rect(2.25, 0, 2.25, 8.0/3),
+				rect(4.5, 0, 1.5, 8.0/3),
+				rect(0, 8.0/3, 2.25, 4.0/3),
+				rect(2.25, 8.0/3, 1.5, 4.0/3),
+				rect(3.75, 8.0/3, 1.5, 4.0/3),
+				rect(5.25, 8.0/3, 0.75, 4.0/3),
+			},
+		},
+		{
+			key:       "strip grid",
+			treemap:   Treemap{Algorithm: TreemapStrip},
+			container: rect(0, 0, 100, 100),
+			items:     []TreemapItem{{Weight: 1}, {Weight: 1}, {Weight: 1}, {Weight: 1}},
+			rects:     []geom.Rect{rect(0, 0, 50, 50), rect(50, 0, 50, 50), rect(0, 50, 50, 50), rect(50, 50, 50, 50)},
+		},
+		{
+			key:       "padding",
+			treemap:   Treemap{Padding: geom.Margin{Top: 10, Bottom: 2, Left: 2, Right: 2}},
+			container: rect(0, 0, 100, 100),
+			items: []TreemapItem{
+				{Weight: 100, Children: []TreemapItem{{Weight: 1}, {Weight: 1, Children: []TreemapItem{{Weight: 1}}}}},
+			},
+			rects: []geom.Rect{
+				rect(0, 0, 100, 100),
+				rect(2, 10, 48, 88),
+				rect(50, 10, 48, 88),
+				rect(52, 20, 44, 76),
+			},
+		},
+		{
+			key:       "zero weights",
+			treemap:   Treemap{Algorithm: TreemapSliceAndDice},
+			container: rect(0, 0, 10, 10),
+			items:     []TreemapItem{{Weight: 0}, {Weight: -1}},
+			rects:     []geom.Rect{rect(0, 0, 0, 0), rect(0, 0, 0, 0)},
+		},
+	}
+
+	for _, test := range tests {
+		if rects := test.treemap.Layout(test.container, test.items); !rectsNearlyEqual(rects, test.rects) {
+			t.Errorf("%s: %v != %v", test.key, rects, test.rects)
+		}
+	}
+}
+
+func TestTreemapZeroWeights(t *testing.T) {
+	items := []TreemapItem{{Weight: 2}, {Weight: 0}, {Weight: 2}, {Weight: -1}}
+	container := rect(10, 20, 100, 50)
+
+	for _, a := range []TreemapAlgorithm{TreemapSquarified, TreemapSliceAndDice, TreemapStrip} {
+		rects := Treemap{Algorithm: a}.Layout(container, items)
+
+		for _, i := range []int{1, 3} {
+			if r := rects[i]; r.W != 0 || r.H != 0 || r.X < 10 || r.X > 110 || r.Y < 20 || r.Y > 70 {
+				t.Errorf("%s: invalid rectangle of an item with a zero weight: %s", a, r)
+			}
+		}
+
+		for _, i := range []int{0, 2} {
+			if r := rects[i]; math.Abs(r.Area()-2500) > 1e-9 {
+				t.Errorf("%s: invalid rectangle of an item with a positive weight: %s", a, r)
+			}
+		}
+	}
+}
+
+func TestTreemapArea(t *testing.T) {
+	items := make([]TreemapItem, 50)
+	total := 0.0
+
+	for i := range items {
+		items[i].Weight = float64(1 + (i*37)%23)
+		total += items[i].Weight
+	}
+
+	container := rect(0, 0, 300, 200)
+
+	for _, a := range []TreemapAlgorithm{TreemapSquarified, TreemapSliceAndDice, TreemapStrip} {
+		rects := Treemap{Algorithm: a}.Layout(container, items)
+
+		for i, r := range rects {
+			area := items[i].Weight * container.Area() / total
+
+			if math.Abs(r.Area()-area) > 1e-6 {
+				t.Errorf("%s: rectangle %d has an invalid area: %g != %g", a, i, r.Area(), area)
+			}
+
+			if !container.ContainsRect(rect(r.X+1e-9, r.Y+1e-9, r.W-2e-9, r.H-2e-9)) {
+				t.Errorf("%s: rectangle %d is outside of the container: %s", a, i, r)
+			}
+
+			for j, r1 := range rects[i+1:] {
+				if s := r.Intersect(r1); s.W > 1e-9 && s.H > 1e-9 {
+					t.Errorf("%s: rectangles %d and %d overlap: %s %s", a, i, i+j+1, r, r1)
+				}
+			}
+		}
+	}
+}
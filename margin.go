@@ -73,6 +73,17 @@ func (m Margin) ShrinkRect(r Rect) Rect {
 	return s
 }
 
+// Add returns the sum of the margin and the one given as argument, which is
+// the margin obtained by applying both of them around a rectangle.
+func (m Margin) Add(m1 Margin) Margin {
+	return Margin{
+		Top:    m.Top + m1.Top,
+		Bottom: m.Bottom + m1.Bottom,
+		Left:   m.Left + m1.Left,
+		Right:  m.Right + m1.Right,
+	}
+}
+
 // Width returns the sum of the left and right values of the given margin.
 func (m Margin) Width() float64 {
 	return m.Left + m.Right
@@ -95,3 +106,107 @@ func (m Margin) Size() Size {
 func (m Margin) String() string {
 	return fmt.Sprintf("margin { top = %g, bottom = %g, left = %g, right = %g }", m.Top, m.Bottom, m.Left, m.Right)
 }
+
+// MarshalText satisfies the encoding.TextMarshaler interface, the margin is
+// formatted like the String method does but with the exact values of its
+// components.
+func (m Margin) MarshalText() ([]byte, error) {
+	b := []byte("margin { top = ")
+	b = appendFloat(b, m.Top)
+	b = append(b, ", bottom = "...)
+	b = appendFloat(b, m.Bottom)
+	b = append(b, ", left = "...)
+	b = appendFloat(b, m.Left)
+	b = append(b, ", right = "...)
+	b = appendFloat(b, m.Right)
+	return append(b, " }"...), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface, it parses the
+// format produced by MarshalText and String.
+//
+// The components may be given in any order, and the ones that are omitted are
+// set to zero.
+func (m *Margin) UnmarshalText(b []byte) error {
+	var n Margin
+	p := textParser{typ: "margin", text: string(b)}
+
+	if p.word() != "margin" {
+		p.off = 0
+		return p.errorf("expected \"margin\"")
+	}
+
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, 4)
+
+	for p.peek() != '}' {
+		if len(seen) != 0 {
+			if err := p.expect(','); err != nil {
+				return err
+			}
+		}
+
+		p.skipSpaces()
+		off := p.off
+		key := p.word()
+		var v *float64
+
+		switch key {
+		case "top":
+			v = &n.Top
+		case "bottom":
+			v = &n.Bottom
+		case "left":
+			v = &n.Left
+		case "right":
+			v = &n.Right
+		default:
+			p.off = off
+			return p.errorf("unknown margin component %q", key)
+		}
+
+		if seen[key] {
+			p.off = off
+			return p.errorf("duplicate margin component %q", key)
+		}
+
+		seen[key] = true
+
+		if err := p.expect('='); err != nil {
+			return err
+		}
+
+		f, err := p.float()
+
+		if err != nil {
+			return err
+		}
+
+		*v = f
+	}
+
+	if err := p.expect('}'); err != nil {
+		return err
+	}
+
+	if err := p.end(); err != nil {
+		return err
+	}
+
+	*m = n
+	return nil
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface. Because of the
+// MarshalText method, the encoding/json package encodes margins as strings like
+// "margin { top = 1, bottom = 2, left = 3, right = 4 }" instead of objects like
+// {"Top": 1, "Bottom": 2, "Left": 3, "Right": 4}, UnmarshalJSON accepts both
+// forms so values encoded by earlier versions of the package can still be
+// decoded.
+func (m *Margin) UnmarshalJSON(b []byte) error {
+	type margin Margin
+	return unmarshalJSON(b, m, (*margin)(m))
+}
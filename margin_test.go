@@ -117,6 +117,14 @@ func TestMarginHeight(t *testing.T) {
 	}
 }
 
+func TestMarginAdd(t *testing.T) {
+	m := Margin{Top: 1, Bottom: 2, Left: 3, Right: 4}.Add(MakeMargin(1))
+
+	if m != (Margin{Top: 2, Bottom: 3, Left: 4, Right: 5}) {
+		t.Error("invalid margin returned by the Add method:", m)
+	}
+}
+
 func TestMarginSize(t *testing.T) {
 	m := MakeMargin(0.25)
 	s := m.Size()
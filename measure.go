@@ -0,0 +1,154 @@
+package geom
+
+import "sort"
+
+// A PathMeasure computes the length of a path, and positions or pieces of the
+// path located at given distances from its start.
+//
+// Distances are measured along the path, every sub-path following the previous
+// one. The length of closed sub-paths includes the segment that closes them.
+type PathMeasure struct {
+	segs   []measureSegment
+	length float64
+}
+
+type measureSegment struct {
+	curve   curve
+	start   float64
+	length  float64
+	contour int
+	last    bool
+	closed  bool
+	skip    bool
+}
+
+// MakePathMeasure constructs a PathMeasure value which measures the path given
+// as argument.
+func MakePathMeasure(p Path) PathMeasure {
+	m := PathMeasure{}
+
+	for i, c := range contours(p) {
+		for j, cv := range c.curves {
+			l := cv.length()
+			m.segs = append(m.segs, measureSegment{
+				curve:   cv,
+				start:   m.length,
+				length:  l,
+				contour: i,
+				last:    j == len(c.curves)-1,
+				closed:  c.closed,
+				skip:    c.implicit && j == len(c.curves)-1,
+			})
+			m.length += l
+		}
+	}
+
+	return m
+}
+
+// Length returns the total length of the measured path.
+func (m *PathMeasure) Length() float64 {
+	return m.length
+}
+
+// PointAt returns the position at distance d from the start of the path, and
+// the angle (in radians) of the tangent to the path at this position.
+//
+// The distance is clamped to the range [0, Length()], and the zero-value is
+// returned for empty paths.
+func (m *PathMeasure) PointAt(d float64) (Point, float64) {
+	if len(m.segs) == 0 {
+		return Point{}, 0
+	}
+
+	i := m.segmentAt(d)
+	s := m.segs[i]
+	t := s.curve.paramAt(d - s.start)
+	return s.curve.point(t), s.curve.tangent(t).Angle()
+}
+
+// Segment returns the piece of the path between the distances d0 and d1 from
+// the start of the path. The returned path contains one sub-path for each of
+// the sub-paths of the original path that the piece goes through, and curves
+// are split at the exact positions of d0 and d1.
+//
+// Closed sub-paths that are entirely contained in the piece are also closed in
+// the returned path.
+func (m *PathMeasure) Segment(d0 float64, d1 float64) Path {
+	out := Path{}
+
+	if d0 < 0 {
+		d0 = 0
+	}
+
+	if d1 > m.length {
+		d1 = m.length
+	}
+
+	if d0 > d1 || len(m.segs) == 0 {
+		return out
+	}
+
+	contour := -1
+
+	for i := m.segmentAt(d0); i < len(m.segs); i++ {
+		s := m.segs[i]
+
+		if contour >= 0 && s.start >= d1 {
+			break
+		}
+
+		if s.contour != contour {
+			contour = s.contour
+			out.MoveTo(s.curve.point(s.curve.paramAt(d0 - s.start)))
+		}
+
+		// The whole closed sub-path is part of the segment, its closing line
+		// is replaced by a ClosePath element.
+		if s.last && s.closed && s.start+s.length <= d1 && m.contourStart(i) >= d0 {
+			if !s.skip {
+				s.curve.appendTo(&out)
+			}
+			out.Close()
+			continue
+		}
+
+		t0 := s.curve.paramAt(d0 - s.start)
+		t1 := s.curve.paramAt(d1 - s.start)
+		s.curve.sub(t0, t1).appendTo(&out)
+	}
+
+	return out
+}
+
+// contourStart returns the distance where the sub-path containing the segment
+// at index i starts.
+func (m *PathMeasure) contourStart(i int) float64 {
+	c := m.segs[i].contour
+
+	for i > 0 && m.segs[i-1].contour == c {
+		i--
+	}
+
+	return m.segs[i].start
+}
+
+// segmentAt returns the index of the segment at distance d.
+func (m *PathMeasure) segmentAt(d float64) int {
+	i := sort.Search(len(m.segs), func(i int) bool {
+		return m.segs[i].start+m.segs[i].length > d
+	})
+
+	if i == len(m.segs) {
+		i--
+	}
+
+	return i
+}
+
+// Length computes and returns the length of the path, closed sub-paths include
+// the segment that closes them.
+func (p *Path) Length() float64 {
+	m := MakePathMeasure(*p)
+	return m.Length()
+}
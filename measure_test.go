@@ -0,0 +1,177 @@
+package geom
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestPathLength(t *testing.T) {
+	open := Path{}
+	open.MoveTo(Point{0, 0})
+	open.LineTo(Point{3, 4})
+	open.LineTo(Point{3, 10})
+
+	circle := Path{}
+	circle.MoveTo(Point{1, 0})
+	appendEllipseSegments(&circle, Point{}, Size{1, 1}, 0, 0, 2*math.Pi, Point{1, 0})
+	circle.Close()
+
+	tests := []struct {
+		path      Path
+		length    float64
+		tolerance float64
+	}{
+		{Path{}, 0, 0},
+		{open, 11, epsilon},
+		{Rect{0, 0, 2, 3}.Path(), 10, epsilon},
+		{circle, 2 * math.Pi, 1e-3},
+	}
+
+	for _, test := range tests {
+		if l := test.path.Length(); !nearlyEqualTolerance(l, test.length, test.tolerance) {
+			t.Errorf("%v: invalid length: %g != %g", test.path, l, test.length)
+		}
+	}
+}
+
+func TestPathMeasurePointAt(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.LineTo(Point{10, 0})
+	p.LineTo(Point{10, 10})
+	p.MoveTo(Point{20, 0})
+	p.QuadCurveTo(Point{25, 0}, Point{30, 0})
+
+	m := MakePathMeasure(p)
+
+	if l := m.Length(); !nearlyEqual(l, 30) {
+		t.Errorf("invalid length: %g", l)
+	}
+
+	tests := []struct {
+		d     float64
+		point Point
+		angle float64
+	}{
+		{-1, Point{0, 0}, 0},
+		{0, Point{0, 0}, 0},
+		{5, Point{5, 0}, 0},
+		{15, Point{10, 5}, math.Pi / 2},
+		{25, Point{25, 0}, 0},
+		{30, Point{30, 0}, 0},
+		{40, Point{30, 0}, 0},
+	}
+
+	for _, test := range tests {
+		pt, angle := m.PointAt(test.d)
+
+		if !pointsNearlyEqual(pt, test.point) || !nearlyEqual(angle, test.angle) {
+			t.Errorf("PointAt(%g): %v, %g != %v, %g", test.d, pt, angle, test.point, test.angle)
+		}
+	}
+}
+
+func TestPathMeasurePointAtEmpty(t *testing.T) {
+	m := MakePathMeasure(Path{})
+
+	if pt, angle := m.PointAt(1); pt != (Point{}) || angle != 0 {
+		t.Errorf("invalid point of empty path: %v, %g", pt, angle)
+	}
+}
+
+func TestPathMeasureSegment(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.LineTo(Point{10, 0})
+	p.LineTo(Point{10, 10})
+	p.MoveTo(Point{20, 0})
+	p.LineTo(Point{30, 0})
+
+	m := MakePathMeasure(p)
+
+	seg := func(points ...Point) Path {
+		s := Path{}
+		s.MoveTo(points[0])
+		for _, pt := range points[1:] {
+			s.LineTo(pt)
+		}
+		return s
+	}
+
+	tests := []struct {
+		d0   float64
+		d1   float64
+		path Path
+	}{
+		{2, 8, seg(Point{2, 0}, Point{8, 0})},
+		{5, 15, seg(Point{5, 0}, Point{10, 0}, Point{10, 5})},
+		{10, 20, seg(Point{10, 0}, Point{10, 10})},
+		{-5, 50, p},
+		{8, 2, Path{}},
+	}
+
+	for _, test := range tests {
+		if s := m.Segment(test.d0, test.d1); !reflect.DeepEqual(s, test.path) {
+			t.Errorf("Segment(%g, %g): %v != %v", test.d0, test.d1, s, test.path)
+		}
+	}
+
+	// Crossing sub-paths produces one sub-path for each of them.
+	s := m.Segment(15, 25)
+	r := Path{}
+	r.MoveTo(Point{10, 5})
+	r.LineTo(Point{10, 10})
+	r.MoveTo(Point{20, 0})
+	r.LineTo(Point{25, 0})
+
+	if !reflect.DeepEqual(s, r) {
+		t.Errorf("Segment(15, 25): %v != %v", s, r)
+	}
+}
+
+func TestPathMeasureSegmentClosed(t *testing.T) {
+	p := Rect{0, 0, 10, 10}.Path()
+	m := MakePathMeasure(p)
+
+	if s := m.Segment(0, m.Length()); !reflect.DeepEqual(s, p) {
+		t.Errorf("the whole closed path was not preserved: %v", s)
+	}
+
+	s := m.Segment(5, 40)
+	r := Path{}
+	r.MoveTo(Point{5, 0})
+	r.LineTo(Point{10, 0})
+	r.LineTo(Point{10, 10})
+	r.LineTo(Point{0, 10})
+	r.LineTo(Point{0, 0})
+
+	if !reflect.DeepEqual(s, r) {
+		t.Errorf("Segment(5, 40): %v != %v", s, r)
+	}
+}
+
+func TestPathMeasureSegmentCurve(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.CubicCurveTo(Point{0, 10}, Point{10, 10}, Point{10, 0})
+
+	m := MakePathMeasure(p)
+	l := m.Length()
+	s := m.Segment(l/4, 3*l/4)
+
+	if n := len(s.Elements); n != 2 || s.Elements[1].Type != CubicCurveTo {
+		t.Fatalf("invalid segment: %v", s)
+	}
+
+	if sl := s.Length(); !nearlyEqualTolerance(sl, l/2, 1e-6) {
+		t.Errorf("invalid segment length: %g != %g", sl, l/2)
+	}
+
+	p0, _ := m.PointAt(l / 4)
+	p1, _ := m.PointAt(3 * l / 4)
+
+	if !pointsNearlyEqual(s.Elements[0].Points[0], p0) || !pointsNearlyEqual(s.Elements[1].Points[2], p1) {
+		t.Errorf("invalid segment end points: %v", s)
+	}
+}
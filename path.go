@@ -1,5 +1,7 @@
 package geom
 
+import "math"
+
 // PathElementType is an enumeration representing the different kinds of path
 // elements supported by 2D paths.
 type PathElementType int
@@ -104,6 +106,35 @@ func AppendPolygon(path Path, points ...Point) Path {
 	return path
 }
 
+// MarshalText satisfies the encoding.TextMarshaler interface, the path is
+// formatted as SVG path data with the exact values of its coordinates.
+func (p Path) MarshalText() ([]byte, error) {
+	return []byte(FormatSVGPath(p, -1)), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface, it parses SVG
+// path data and returns an error of type *SVGPathError when it is malformed.
+func (p *Path) UnmarshalText(b []byte) error {
+	q, err := ParseSVGPath(string(b))
+
+	if err != nil {
+		return err
+	}
+
+	*p = q
+	return nil
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface. Because of the
+// MarshalText method, the encoding/json package encodes paths as strings of SVG
+// path data instead of objects with a list of elements, UnmarshalJSON accepts
+// both forms so values encoded by earlier versions of the package can still be
+// decoded.
+func (p *Path) UnmarshalJSON(b []byte) error {
+	type path Path
+	return unmarshalJSON(b, p, (*path)(p))
+}
+
 // MoveTo appends a path element that moves the current path position to the
 // point given as argument.
 func (p *Path) MoveTo(pt Point) {
@@ -224,3 +255,98 @@ func (p *Path) ensureStartWithMoveTo() {
 		p.MoveTo(p.LastPoint())
 	}
 }
+
+// ControlBounds computes and returns the smallest rectangle containing all the
+// points of the path, including the control points of curves.
+//
+// The returned rectangle always contains the shape drawn by the path but may
+// be larger than the exact bounds, it is however much cheaper to compute than
+// the value returned by Bounds.
+func (p *Path) ControlBounds() Rect {
+	b := boundsBuilder{}
+
+	for _, e := range p.Elements {
+		switch e.Type {
+		case MoveTo, LineTo:
+			b.add(e.Points[0])
+
+		case QuadCurveTo:
+			b.add(e.Points[0])
+			b.add(e.Points[1])
+
+		case CubicCurveTo:
+			b.add(e.Points[0])
+			b.add(e.Points[1])
+			b.add(e.Points[2])
+		}
+	}
+
+	return b.rect()
+}
+
+// Bounds computes and returns the smallest rectangle containing the shape drawn
+// by the path. Unlike ControlBounds, the extrema of quadratic and cubic curves
+// are solved exactly so control points lying outside of the curves don't grow
+// the returned rectangle.
+func (p *Path) Bounds() Rect {
+	b := boundsBuilder{}
+	ts := make([]float64, 0, 4)
+
+	for i, e := range p.Elements {
+		switch e.Type {
+		case MoveTo, LineTo:
+			b.add(e.Points[0])
+
+		case QuadCurveTo:
+			p0, p1, p2 := p.lastPointAt(i-1), e.Points[0], e.Points[1]
+			ts = quadExtrema(ts[:0], p0.X, p1.X, p2.X)
+			ts = quadExtrema(ts, p0.Y, p1.Y, p2.Y)
+
+			for _, t := range ts {
+				b.add(quadPoint(p0, p1, p2, t))
+			}
+
+			b.add(p2)
+
+		case CubicCurveTo:
+			p0, p1, p2, p3 := p.lastPointAt(i-1), e.Points[0], e.Points[1], e.Points[2]
+			ts = cubicExtrema(ts[:0], p0.X, p1.X, p2.X, p3.X)
+			ts = cubicExtrema(ts, p0.Y, p1.Y, p2.Y, p3.Y)
+
+			for _, t := range ts {
+				b.add(cubicPoint(p0, p1, p2, p3, t))
+			}
+
+			b.add(p3)
+		}
+	}
+
+	return b.rect()
+}
+
+type boundsBuilder struct {
+	min   Point
+	max   Point
+	valid bool
+}
+
+func (b *boundsBuilder) add(p Point) {
+	if !b.valid {
+		b.min, b.max, b.valid = p, p, true
+		return
+	}
+
+	b.min.X = math.Min(b.min.X, p.X)
+	b.min.Y = math.Min(b.min.Y, p.Y)
+	b.max.X = math.Max(b.max.X, p.X)
+	b.max.Y = math.Max(b.max.Y, p.Y)
+}
+
+func (b *boundsBuilder) rect() Rect {
+	return Rect{
+		X: b.min.X,
+		Y: b.min.Y,
+		W: b.max.X - b.min.X,
+		H: b.max.Y - b.min.Y,
+	}
+}
@@ -265,3 +265,49 @@ func TestAppendPath(t *testing.T) {
 		t.Errorf("invalid path build by appending a path to another: %#v", p1)
 	}
 }
+
+func TestPathControlBounds(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{1, 1})
+	p.CubicCurveTo(Point{0, 3}, Point{4, 3}, Point{3, 1})
+
+	if b := p.ControlBounds(); b != (Rect{0, 1, 4, 2}) {
+		t.Error("invalid control bounds:", b)
+	}
+}
+
+func TestPathControlBoundsEmpty(t *testing.T) {
+	p := Path{}
+
+	if b := p.ControlBounds(); b != (Rect{}) {
+		t.Error("invalid control bounds of an empty path:", b)
+	}
+}
+
+func TestPathBoundsQuadCurveTo(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.QuadCurveTo(Point{1, 2}, Point{2, 0})
+
+	if b := p.Bounds(); !rectsNearlyEqual(b, Rect{0, 0, 2, 1}) {
+		t.Error("invalid bounds of a quadratic curve:", b)
+	}
+}
+
+func TestPathBoundsCubicCurveTo(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.CubicCurveTo(Point{0, 4}, Point{2, 4}, Point{2, 0})
+
+	if b := p.Bounds(); !rectsNearlyEqual(b, Rect{0, 0, 2, 3}) {
+		t.Error("invalid bounds of a cubic curve:", b)
+	}
+}
+
+func TestPathBoundsLines(t *testing.T) {
+	p := Rect{1, 2, 3, 4}.Path()
+
+	if b := p.Bounds(); b != (Rect{1, 2, 3, 4}) {
+		t.Error("invalid bounds of a rectangle path:", b)
+	}
+}
@@ -1,6 +1,9 @@
 package geom
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 // The Point type represents 2D coordinates.
 type Point struct {
@@ -8,6 +11,16 @@ type Point struct {
 	Y float64
 }
 
+// MakePolar constructs a Point value from polar coordinates, the distance to
+// the origin and the angle (in radians) with the x axis.
+func MakePolar(radius float64, angle float64) Point {
+	sin, cos := math.Sincos(angle)
+	return Point{
+		X: radius * cos,
+		Y: radius * sin,
+	}
+}
+
 // Zero checks if the receiver has the zero-value (both the x and y components
 // are zero).
 func (p Point) Zero() bool {
@@ -19,6 +32,36 @@ func (p Point) String() string {
 	return fmt.Sprintf("(%.6g, %.6g)", p.X, p.Y)
 }
 
+// MarshalText satisfies the encoding.TextMarshaler interface, the point is
+// formatted like the String method does but with the exact values of its
+// coordinates.
+func (p Point) MarshalText() ([]byte, error) {
+	return appendFloats(nil, "(", ")", p.X, p.Y), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface, it parses the
+// format produced by MarshalText and String.
+func (p *Point) UnmarshalText(b []byte) error {
+	var q Point
+
+	if err := parseFloats("point", b, '(', ')', &q.X, &q.Y); err != nil {
+		return err
+	}
+
+	*p = q
+	return nil
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface. Because of the
+// MarshalText method, the encoding/json package encodes points as strings like
+// "(1, 2)" instead of objects like {"X": 1, "Y": 2}, UnmarshalJSON accepts both
+// forms so values encoded by earlier versions of the package can still be
+// decoded.
+func (p *Point) UnmarshalJSON(b []byte) error {
+	type point Point
+	return unmarshalJSON(b, p, (*point)(p))
+}
+
 // WithOrigin translate the receiver to a coordinate system with origin given as
 // argument and returns the modified point.
 func (p Point) WithOrigin(origin Point) Point {
@@ -27,3 +70,110 @@ func (p Point) WithOrigin(origin Point) Point {
 		Y: p.Y - origin.Y,
 	}
 }
+
+// Add returns the sum of the receiver and the point given as argument.
+func (p Point) Add(p1 Point) Point {
+	return Point{
+		X: p.X + p1.X,
+		Y: p.Y + p1.Y,
+	}
+}
+
+// Sub returns the difference between the receiver and the point given as
+// argument.
+func (p Point) Sub(p1 Point) Point {
+	return Point{
+		X: p.X - p1.X,
+		Y: p.Y - p1.Y,
+	}
+}
+
+// Scale returns the receiver with both components multiplied by f.
+func (p Point) Scale(f float64) Point {
+	return Point{
+		X: p.X * f,
+		Y: p.Y * f,
+	}
+}
+
+// Neg returns the receiver with both components negated.
+func (p Point) Neg() Point {
+	return Point{
+		X: -p.X,
+		Y: -p.Y,
+	}
+}
+
+// Dot computes and returns the dot product of the receiver and the point given
+// as argument.
+func (p Point) Dot(p1 Point) float64 {
+	return p.X*p1.X + p.Y*p1.Y
+}
+
+// Cross computes and returns the z component of the cross product of the
+// receiver and the point given as argument. The value is positive when p1 is
+// on the counter-clockwise side of p (in a coordinate system where y points up).
+func (p Point) Cross(p1 Point) float64 {
+	return p.X*p1.Y - p.Y*p1.X
+}
+
+// Length returns the distance between the point and the origin.
+func (p Point) Length() float64 {
+	return math.Hypot(p.X, p.Y)
+}
+
+// Normalize returns a point in the same direction as the receiver but with a
+// length of one. The zero-value is returned unchanged.
+func (p Point) Normalize() Point {
+	if l := p.Length(); l != 0 {
+		return p.Scale(1 / l)
+	}
+	return p
+}
+
+// Distance returns the distance between the receiver and the point given as
+// argument.
+func (p Point) Distance(p1 Point) float64 {
+	return p.Sub(p1).Length()
+}
+
+// Lerp linearly interpolates between the receiver and the point given as
+// argument, t = 0 returns the receiver and t = 1 returns p1.
+func (p Point) Lerp(p1 Point, t float64) Point {
+	return Point{
+		X: p.X + (p1.X-p.X)*t,
+		Y: p.Y + (p1.Y-p.Y)*t,
+	}
+}
+
+// Rotate returns the receiver rotated around the origin by the angle given as
+// argument (in radians).
+func (p Point) Rotate(angle float64) Point {
+	sin, cos := math.Sincos(angle)
+	return Point{
+		X: p.X*cos - p.Y*sin,
+		Y: p.X*sin + p.Y*cos,
+	}
+}
+
+// Perp returns the receiver rotated by a quarter turn, which is a vector
+// perpendicular to the receiver with the same length.
+func (p Point) Perp() Point {
+	return Point{
+		X: -p.Y,
+		Y: p.X,
+	}
+}
+
+// Angle returns the angle (in radians) between the x axis and the vector from
+// the origin to the receiver, in the range [-Pi, Pi].
+func (p Point) Angle() float64 {
+	return math.Atan2(p.Y, p.X)
+}
+
+// AngleTo returns the signed angle (in radians) to rotate the receiver by to
+// get a vector with the same direction as the point given as argument, in the
+// range [-Pi, Pi].
+func (p Point) AngleTo(p1 Point) float64 {
+	return math.Atan2(p.Cross(p1), p.Dot(p1))
+}
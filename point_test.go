@@ -1,6 +1,9 @@
 package geom
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestPointZeroTrue(t *testing.T) {
 	p := Point{}
@@ -36,3 +39,97 @@ func TestPointWithOrigin(t *testing.T) {
 		t.Error("invalid point coordinates when changing origin:", p, o, q)
 	}
 }
+
+func TestMakePolar(t *testing.T) {
+	if p := MakePolar(2, math.Pi/2); !pointsNearlyEqual(p, Point{0, 2}) {
+		t.Error("invalid point constructed from polar coordinates:", p)
+	}
+}
+
+func TestPointAdd(t *testing.T) {
+	if p := (Point{1, 2}).Add(Point{3, 4}); p != (Point{4, 6}) {
+		t.Error("invalid sum of points:", p)
+	}
+}
+
+func TestPointSub(t *testing.T) {
+	if p := (Point{1, 2}).Sub(Point{3, 5}); p != (Point{-2, -3}) {
+		t.Error("invalid difference of points:", p)
+	}
+}
+
+func TestPointScale(t *testing.T) {
+	if p := (Point{1, 2}).Scale(3); p != (Point{3, 6}) {
+		t.Error("invalid scaled point:", p)
+	}
+}
+
+func TestPointNeg(t *testing.T) {
+	if p := (Point{1, -2}).Neg(); p != (Point{-1, 2}) {
+		t.Error("invalid negated point:", p)
+	}
+}
+
+func TestPointDot(t *testing.T) {
+	if d := (Point{1, 2}).Dot(Point{3, 4}); d != 11 {
+		t.Error("invalid dot product:", d)
+	}
+}
+
+func TestPointCross(t *testing.T) {
+	if c := (Point{1, 0}).Cross(Point{0, 1}); c != 1 {
+		t.Error("invalid cross product:", c)
+	}
+}
+
+func TestPointLength(t *testing.T) {
+	if l := (Point{3, 4}).Length(); l != 5 {
+		t.Error("invalid point length:", l)
+	}
+}
+
+func TestPointNormalize(t *testing.T) {
+	if p := (Point{3, 4}).Normalize(); !pointsNearlyEqual(p, Point{0.6, 0.8}) {
+		t.Error("invalid normalized point:", p)
+	}
+
+	if p := (Point{}).Normalize(); p != (Point{}) {
+		t.Error("normalizing the zero-value must return the zero-value:", p)
+	}
+}
+
+func TestPointDistance(t *testing.T) {
+	if d := (Point{1, 1}).Distance(Point{4, 5}); d != 5 {
+		t.Error("invalid distance between points:", d)
+	}
+}
+
+func TestPointLerp(t *testing.T) {
+	if p := (Point{0, 0}).Lerp(Point{2, 4}, 0.25); p != (Point{0.5, 1}) {
+		t.Error("invalid interpolated point:", p)
+	}
+}
+
+func TestPointRotate(t *testing.T) {
+	if p := (Point{1, 0}).Rotate(math.Pi / 2); !pointsNearlyEqual(p, Point{0, 1}) {
+		t.Error("invalid rotated point:", p)
+	}
+}
+
+func TestPointPerp(t *testing.T) {
+	if p := (Point{1, 2}).Perp(); p != (Point{-2, 1}) {
+		t.Error("invalid perpendicular point:", p)
+	}
+}
+
+func TestPointAngle(t *testing.T) {
+	if a := (Point{0, 1}).Angle(); !nearlyEqual(a, math.Pi/2) {
+		t.Error("invalid point angle:", a)
+	}
+}
+
+func TestPointAngleTo(t *testing.T) {
+	if a := (Point{1, 0}).AngleTo(Point{0, -1}); !nearlyEqual(a, -math.Pi/2) {
+		t.Error("invalid angle between points:", a)
+	}
+}
@@ -0,0 +1,297 @@
+package geom
+
+import (
+	"image"
+	"math"
+)
+
+// rasterTolerance is the maximum distance in pixels between curves and the line
+// segments approximating them when paths are rasterized.
+const rasterTolerance = 0.02
+
+// Rasterize computes the anti-aliased coverage of the area filled by the path
+// according to the fill rule, and stores it in buf.
+//
+// The buffer represents the pixels of the bounds rectangle, stored row by row
+// with a stride of bounds.Dx(), and must have a length of at least
+// bounds.Dx()*bounds.Dy(). Path coordinates are expressed in the same space as
+// the bounds, the pixel (x, y) covering the area from (x, y) to (x+1, y+1).
+//
+// Coverage values range from 0 to 1 and are the exact fraction of each pixel
+// covered by the intersection of the path and the clip rectangle, curves being
+// approximated by line segments. Every sub-path is implicitly closed, and the
+// whole buffer is overwritten, pixels outside of the clip rectangle are set to
+// zero.
+//
+// The coverage is computed with the signed area accumulation technique: each
+// line segment adds the signed area it covers in the pixels that it crosses,
+// and the coverage is obtained by summing the accumulated values from left to
+// right.
+func Rasterize(buf []float32, bounds image.Rectangle, path Path, rule FillRule, clip Rect) {
+	w, h := bounds.Dx(), bounds.Dy()
+
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	buf = buf[:w*h]
+
+	for i := range buf {
+		buf[i] = 0
+	}
+
+	clip = clip.Abs().Intersect(Rect{
+		X: float64(bounds.Min.X),
+		Y: float64(bounds.Min.Y),
+		W: float64(w),
+		H: float64(h),
+	})
+
+	if clip.Empty() {
+		return
+	}
+
+	r := rasterizer{
+		buf:    buf,
+		w:      w,
+		h:      h,
+		origin: Point{float64(bounds.Min.X), float64(bounds.Min.Y)},
+		clip:   clip,
+	}
+
+	var start, last Point
+	var open bool
+
+	path.flatten(rasterTolerance, func(op PathElementType, pts []Point) {
+		switch op {
+		case MoveTo:
+			if open {
+				r.clipLine(last, start)
+			}
+			start, last, open = pts[0], pts[0], true
+
+		case LineTo:
+			for _, pt := range pts {
+				r.clipLine(last, pt)
+				last = pt
+			}
+
+		case ClosePath:
+			r.clipLine(last, start)
+			last, open = start, false
+		}
+	})
+
+	if open {
+		r.clipLine(last, start)
+	}
+
+	for y := 0; y < h; y++ {
+		row := buf[y*w : (y+1)*w]
+		acc := float32(0)
+
+		for x, a := range row {
+			acc += a
+			row[x] = coverage(acc, rule)
+		}
+	}
+}
+
+// RasterizeAlpha computes the anti-aliased coverage of the area filled by the
+// path according to the fill rule, and stores it in the alpha image, which can
+// then be used as a mask with the image/draw package.
+//
+// Only the pixels of the image which intersect with the clip rectangle are
+// modified, the other ones are left untouched. See Rasterize for details on how
+// the coverage is computed.
+func RasterizeAlpha(dst *image.Alpha, path Path, rule FillRule, clip Rect) {
+	bounds := clip.ImageRect(RoundOutward).Intersect(dst.Rect)
+
+	if bounds.Empty() {
+		return
+	}
+
+	w := bounds.Dx()
+	buf := make([]float32, w*bounds.Dy())
+	Rasterize(buf, bounds, path, rule, clip)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row := buf[(y-bounds.Min.Y)*w:]
+		pix := dst.Pix[dst.PixOffset(bounds.Min.X, y):]
+
+		for x := 0; x < w; x++ {
+			pix[x] = uint8(row[x]*255 + 0.5)
+		}
+	}
+}
+
+// coverage converts the accumulated signed area of a pixel to a coverage value
+// according to the fill rule.
+func coverage(a float32, rule FillRule) float32 {
+	if a < 0 {
+		a = -a
+	}
+
+	if rule == EvenOdd {
+		a = float32(math.Mod(float64(a), 2))
+
+		if a > 1 {
+			a = 2 - a
+		}
+	}
+
+	if a > 1 {
+		a = 1
+	}
+
+	return a
+}
+
+type rasterizer struct {
+	buf    []float32
+	w      int
+	h      int
+	origin Point
+	clip   Rect
+}
+
+// clipLine clips the line segment going from a to b to the clip rectangle, and
+// accumulates the pieces that remain.
+//
+// Parts of the segment above or below the clip rectangle are removed since they
+// don't affect the coverage, while parts on the left or right side are moved to
+// the edges of the clip rectangle, where they still contribute to the coverage
+// of the pixels on their right.
+func (r *rasterizer) clipLine(a Point, b Point) {
+	y0, y1 := r.clip.Y, r.clip.Y+r.clip.H
+
+	if a.Y == b.Y || (a.Y <= y0 && b.Y <= y0) || (a.Y >= y1 && b.Y >= y1) {
+		return
+	}
+
+	// Parameters of the segment where it crosses the top and bottom edges.
+	t0 := (y0 - a.Y) / (b.Y - a.Y)
+	t1 := (y1 - a.Y) / (b.Y - a.Y)
+
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+
+	t0 = math.Max(t0, 0)
+	t1 = math.Min(t1, 1)
+	ts := [4]float64{t0, 0, 0, 0}
+	n := 1
+
+	// Parameters of the segment where it crosses the left and right edges,
+	// sorted in increasing order.
+	if a.X != b.X {
+		for _, x := range [2]float64{r.clip.X, r.clip.X + r.clip.W} {
+			if t := (x - a.X) / (b.X - a.X); t > t0 && t < t1 {
+				ts[n] = t
+				n++
+			}
+		}
+
+		if n == 3 && ts[1] > ts[2] {
+			ts[1], ts[2] = ts[2], ts[1]
+		}
+	}
+
+	ts[n] = t1
+	n++
+
+	p0 := r.clipPoint(a, b, ts[0])
+
+	for _, t := range ts[1:n] {
+		p1 := r.clipPoint(a, b, t)
+		r.line(p0.Sub(r.origin), p1.Sub(r.origin))
+		p0 = p1
+	}
+}
+
+// clipPoint returns the point of the segment from a to b at parameter t, moved
+// inside of the clip rectangle.
+func (r *rasterizer) clipPoint(a Point, b Point, t float64) Point {
+	p := a.Lerp(b, t)
+	p.X = math.Max(r.clip.X, math.Min(r.clip.X+r.clip.W, p.X))
+	p.Y = math.Max(r.clip.Y, math.Min(r.clip.Y+r.clip.H, p.Y))
+	return p
+}
+
+// line accumulates the signed area covered by the line segment going from p0
+// to p1 in the pixels that it crosses, the coordinates are relative to the
+// buffer and expected to be within its bounds.
+func (r *rasterizer) line(p0 Point, p1 Point) {
+	if p0.Y == p1.Y {
+		return
+	}
+
+	dir := 1.0
+
+	if p0.Y > p1.Y {
+		dir = -1
+		p0, p1 = p1, p0
+	}
+
+	dxdy := (p1.X - p0.X) / (p1.Y - p0.Y)
+	x := p0.X
+
+	for y := int(p0.Y); y < r.h && float64(y) < p1.Y; y++ {
+		row := y * r.w
+		dy := math.Min(float64(y+1), p1.Y) - math.Max(float64(y), p0.Y)
+		xnext := x + dxdy*dy
+		d := dy * dir
+		x0, x1 := x, xnext
+
+		if x0 > x1 {
+			x0, x1 = x1, x0
+		}
+
+		x0floor := math.Floor(x0)
+		x0i := int(x0floor)
+		x1ceil := math.Ceil(x1)
+		x1i := int(x1ceil)
+
+		if x1i <= x0i+1 {
+			// The segment stays in a single pixel of the row, the area on its
+			// right is split between this pixel and the next one.
+			xm := 0.5*(x+xnext) - x0floor
+			r.add(row, x0i, d-d*xm)
+			r.add(row, x0i+1, d*xm)
+		} else {
+			s := 1 / (x1 - x0)
+			x0f := x0 - x0floor
+			a0 := 0.5 * s * (1 - x0f) * (1 - x0f)
+			x1f := x1 - x1ceil + 1
+			am := 0.5 * s * x1f * x1f
+			r.add(row, x0i, d*a0)
+
+			if x1i == x0i+2 {
+				r.add(row, x0i+1, d*(1-a0-am))
+			} else {
+				a1 := s * (1.5 - x0f)
+				r.add(row, x0i+1, d*(a1-a0))
+
+				for xi := x0i + 2; xi < x1i-1; xi++ {
+					r.add(row, xi, d*s)
+				}
+
+				a2 := a1 + float64(x1i-x0i-3)*s
+				r.add(row, x1i-1, d*(1-a2-am))
+			}
+
+			r.add(row, x1i, d*am)
+		}
+
+		x = xnext
+	}
+}
+
+// add accumulates a value in the pixel at column x of the row starting at the
+// given offset, values past the end of the row are dropped since they would
+// only affect pixels outside of the buffer.
+func (r *rasterizer) add(row int, x int, v float64) {
+	if x >= 0 && x < r.w {
+		r.buf[row+x] += float32(v)
+	}
+}
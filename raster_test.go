@@ -0,0 +1,216 @@
+package geom
+
+import (
+	"image"
+	"testing"
+)
+
+func rasterize(p Path, rule FillRule, w int, h int) []float32 {
+	buf := make([]float32, w*h)
+	Rasterize(buf, image.Rect(0, 0, w, h), p, rule, Rect{0, 0, float64(w), float64(h)})
+	return buf
+}
+
+func coverageSum(buf []float32) float64 {
+	sum := 0.0
+
+	for _, c := range buf {
+		sum += float64(c)
+	}
+
+	return sum
+}
+
+func TestRasterizeRect(t *testing.T) {
+	buf := rasterize(Rect{2, 3, 4, 2}.Path(), NonZero, 8, 8)
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			c := float32(0)
+
+			if x >= 2 && x < 6 && y >= 3 && y < 5 {
+				c = 1
+			}
+
+			if v := buf[y*8+x]; !nearlyEqualTolerance(float64(v), float64(c), 1e-6) {
+				t.Errorf("invalid coverage at (%d, %d): %g != %g", x, y, v, c)
+			}
+		}
+	}
+}
+
+func TestRasterizePartialCoverage(t *testing.T) {
+	tests := []struct {
+		rect     Rect
+		x        int
+		y        int
+		coverage float64
+	}{
+		{Rect{0.5, 0, 1, 1}, 0, 0, 0.5},
+		{Rect{0.5, 0, 1, 1}, 1, 0, 0.5},
+		{Rect{0.25, 0.5, 0.5, 0.5}, 0, 0, 0.25},
+		{Rect{1.25, 1.25, 1.5, 1.5}, 1, 1, 0.5625},
+		{Rect{1.25, 1.25, 1.5, 1.5}, 2, 1, 0.5625},
+		{Rect{1.25, 1.25, 1.5, 1.5}, 2, 2, 0.5625},
+	}
+
+	for _, test := range tests {
+		buf := rasterize(test.rect.Path(), NonZero, 4, 4)
+
+		if c := buf[test.y*4+test.x]; !nearlyEqualTolerance(float64(c), test.coverage, 1e-6) {
+			t.Errorf("%s: invalid coverage at (%d, %d): %g != %g", test.rect, test.x, test.y, c, test.coverage)
+		}
+	}
+}
+
+func TestRasterizeArea(t *testing.T) {
+	triangle := Path{}
+	triangle.MoveTo(Point{1.3, 0.7})
+	triangle.LineTo(Point{14.2, 3.1})
+	triangle.LineTo(Point{5.5, 12.9})
+	triangle.Close()
+
+	tests := []struct {
+		path Path
+		area float64
+	}{
+		{triangle, pathArea(triangle)},
+		{Circle{Point{8, 8}, 6.5}.Path(), pathArea(Circle{Point{8, 8}, 6.5}.Path())},
+		{RoundedRect{Rect{1, 1, 13.5, 9.25}, MakeCornerRadii(3)}.Path(), pathArea(RoundedRect{Rect{1, 1, 13.5, 9.25}, MakeCornerRadii(3)}.Path())},
+	}
+
+	for _, test := range tests {
+		buf := rasterize(test.path, NonZero, 16, 16)
+
+		if a := coverageSum(buf); !nearlyEqualTolerance(a, test.area, 0.5) {
+			t.Errorf("%v: invalid coverage area: %g != %g", test.path, a, test.area)
+		}
+
+		for _, c := range buf {
+			if c < 0 || c > 1 {
+				t.Errorf("coverage out of range: %g", c)
+				break
+			}
+		}
+	}
+}
+
+func TestRasterizeFillRule(t *testing.T) {
+	// Two nested squares drawn in the same direction, the inner one is a hole
+	// with the even-odd fill rule only.
+	p := AppendRect(Rect{0, 0, 8, 8}.Path(), Rect{2, 2, 4, 4})
+
+	tests := []struct {
+		rule FillRule
+		area float64
+		hole float32
+	}{
+		{NonZero, 64, 1},
+		{EvenOdd, 48, 0},
+	}
+
+	for _, test := range tests {
+		buf := rasterize(p, test.rule, 8, 8)
+
+		if a := coverageSum(buf); !nearlyEqualTolerance(a, test.area, 1e-4) {
+			t.Errorf("%s: invalid coverage area: %g", test.rule, a)
+		}
+
+		if c := buf[4*8+4]; c != test.hole {
+			t.Errorf("%s: invalid coverage in the inner square: %g", test.rule, c)
+		}
+	}
+}
+
+func TestRasterizeOpenPath(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.LineTo(Point{4, 0})
+	p.LineTo(Point{4, 4})
+	p.LineTo(Point{0, 4})
+
+	if a := coverageSum(rasterize(p, NonZero, 4, 4)); !nearlyEqualTolerance(a, 16, 1e-4) {
+		t.Errorf("open sub-paths must be implicitly closed: %g", a)
+	}
+}
+
+func TestRasterizeClip(t *testing.T) {
+	p := Circle{Point{8, 8}, 20}.Path()
+	buf := make([]float32, 16*16)
+
+	for i := range buf {
+		buf[i] = 42
+	}
+
+	Rasterize(buf, image.Rect(0, 0, 16, 16), p, NonZero, Rect{2.5, 3, 10, 5.5})
+
+	if a := coverageSum(buf); !nearlyEqualTolerance(a, 55, 1e-4) {
+		t.Errorf("invalid coverage area: %g", a)
+	}
+
+	if c := buf[3*16+2]; !nearlyEqualTolerance(float64(c), 0.5, 1e-6) {
+		t.Errorf("invalid coverage on the clip edge: %g", c)
+	}
+
+	if c := buf[8*16+5]; !nearlyEqualTolerance(float64(c), 0.5, 1e-6) {
+		t.Errorf("invalid coverage on the clip edge: %g", c)
+	}
+}
+
+func TestRasterizeOffsetBounds(t *testing.T) {
+	buf := make([]float32, 4*4)
+	Rasterize(buf, image.Rect(10, 20, 14, 24), Rect{11, 21, 2, 2}.Path(), NonZero, Rect{0, 0, 100, 100})
+
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			c := float32(0)
+
+			if x >= 1 && x < 3 && y >= 1 && y < 3 {
+				c = 1
+			}
+
+			if v := buf[y*4+x]; !nearlyEqualTolerance(float64(v), float64(c), 1e-6) {
+				t.Errorf("invalid coverage at (%d, %d): %g != %g", x, y, v, c)
+			}
+		}
+	}
+}
+
+func TestRasterizeAlpha(t *testing.T) {
+	img := image.NewAlpha(image.Rect(0, 0, 8, 8))
+
+	for i := range img.Pix {
+		img.Pix[i] = 7
+	}
+
+	RasterizeAlpha(img, Rect{1.5, 1, 4, 4}.Path(), NonZero, Rect{0, 0, 4, 4})
+
+	tests := []struct {
+		x     int
+		y     int
+		alpha uint8
+	}{
+		{0, 0, 0},
+		{1, 1, 128},
+		{2, 2, 255},
+		{3, 3, 255},
+		{4, 4, 7},
+		{6, 2, 7},
+	}
+
+	for _, test := range tests {
+		if a := img.AlphaAt(test.x, test.y).A; a != test.alpha {
+			t.Errorf("invalid alpha at (%d, %d): %d != %d", test.x, test.y, a, test.alpha)
+		}
+	}
+}
+
+func BenchmarkRasterize(b *testing.B) {
+	p := Circle{Point{128, 128}, 120}.Path()
+	p = AppendPath(p, Stroke(Circle{Point{128, 128}, 80}.Path(), StrokeStyle{Width: 10}))
+	buf := make([]float32, 256*256)
+
+	for i := 0; i < b.N; i++ {
+		Rasterize(buf, image.Rect(0, 0, 256, 256), p, EvenOdd, Rect{0, 0, 256, 256})
+	}
+}
@@ -169,6 +169,36 @@ func (r Rect) String() string {
 	return fmt.Sprintf("{ %.6g, %.6g, %.6g, %.6g }", r.X, r.Y, r.W, r.H)
 }
 
+// MarshalText satisfies the encoding.TextMarshaler interface, the rectangle is
+// formatted like the String method does but with the exact values of its
+// components.
+func (r Rect) MarshalText() ([]byte, error) {
+	return appendFloats(nil, "{ ", " }", r.X, r.Y, r.W, r.H), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface, it parses the
+// format produced by MarshalText and String.
+func (r *Rect) UnmarshalText(b []byte) error {
+	var q Rect
+
+	if err := parseFloats("rect", b, '{', '}', &q.X, &q.Y, &q.W, &q.H); err != nil {
+		return err
+	}
+
+	*r = q
+	return nil
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface. Because of the
+// MarshalText method, the encoding/json package encodes rectangles as strings
+// like "{ 1, 2, 3, 4 }" instead of objects like
+// {"X": 1, "Y": 2, "W": 3, "H": 4}, UnmarshalJSON accepts both forms so values
+// encoded by earlier versions of the package can still be decoded.
+func (r *Rect) UnmarshalJSON(b []byte) error {
+	type rect Rect
+	return unmarshalJSON(b, r, (*rect)(r))
+}
+
 // Path satisfies the Shape interface, allowing Rect values to be used with
 // programs that manipulate shapes.
 func (r Rect) Path() Path {
@@ -222,13 +252,10 @@ func MergeRect(list []Rect, rect Rect) []Rect {
 		}
 	}
 
-	// TOOD: more clever merging policies?
-	// -----------------------------------
-	//
-	// - don't merge if the resulting area is greater than keeping rectangles
-	// - look for the merge that creates the smallest area
-	// - optimize memory allocation, only recreate the list if needed
-	// - sort the list by areas in descending order to improve the fast path
+	// More clever merging policies, like refusing merges that increase the
+	// total area or looking for the merge that creates the smallest area, are
+	// implemented by AreaCostPolicy and MaxRectsPolicy for use with the
+	// DamageTracker type.
 	//
 	// While these merges could greatly reduce the areas being redrawn they
 	// could also be expansive to compute for potentially little gain due to
@@ -0,0 +1,355 @@
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+// A Region represents an arbitrary area made of a set of rectangles, and
+// supports exact set operations like union, intersection and subtraction.
+//
+// Regions are stored as a list of horizontal bands sorted from top to bottom,
+// each band containing a list of disjoint intervals sorted from left to right.
+// Adjacent bands with the same intervals are always coalesced, which means two
+// regions covering the same area have the same representation.
+//
+// The zero-value is an empty region, and Region values are immutable, every
+// operation returns a new value.
+type Region struct {
+	bands []regionBand
+}
+
+type regionBand struct {
+	y0 float64
+	y1 float64
+
+	// The x coordinates of the intervals covered by the band, stored in pairs
+	// of start and end values.
+	xs []float64
+}
+
+// MakeRegion constructs a Region value which covers the union of the rectangles
+// given as arguments.
+func MakeRegion(rects ...Rect) Region {
+	r := Region{}
+
+	for _, rect := range rects {
+		r = r.UnionRect(rect)
+	}
+
+	return r
+}
+
+func rectRegion(r Rect) Region {
+	if r = r.Abs(); r.Empty() {
+		return Region{}
+	}
+	return Region{
+		bands: []regionBand{{
+			y0: r.Y,
+			y1: r.Y + r.H,
+			xs: []float64{r.X, r.X + r.W},
+		}},
+	}
+}
+
+// Empty checks whether the region is empty, which means it covers no area.
+func (r Region) Empty() bool {
+	return len(r.bands) == 0
+}
+
+// Bounds returns the smallest rectangle that contains the whole region.
+func (r Region) Bounds() Rect {
+	if r.Empty() {
+		return Rect{}
+	}
+
+	x0 := math.Inf(1)
+	x1 := math.Inf(-1)
+
+	for _, b := range r.bands {
+		x0 = math.Min(x0, b.xs[0])
+		x1 = math.Max(x1, b.xs[len(b.xs)-1])
+	}
+
+	y0 := r.bands[0].y0
+	y1 := r.bands[len(r.bands)-1].y1
+	return Rect{
+		X: x0,
+		Y: y0,
+		W: x1 - x0,
+		H: y1 - y0,
+	}
+}
+
+// Area computes and returns the area covered by the region.
+func (r Region) Area() float64 {
+	area := 0.0
+
+	for _, b := range r.bands {
+		for i := 0; i < len(b.xs); i += 2 {
+			area += (b.xs[i+1] - b.xs[i]) * (b.y1 - b.y0)
+		}
+	}
+
+	return area
+}
+
+// Rects returns the list of disjoint rectangles that the region is made of,
+// sorted from top to bottom and from left to right.
+func (r Region) Rects() []Rect {
+	var rects []Rect
+
+	r.ForEachRect(func(rect Rect) {
+		rects = append(rects, rect)
+	})
+
+	return rects
+}
+
+// ForEachRect calls f with each of the disjoint rectangles that the region is
+// made of, sorted from top to bottom and from left to right.
+func (r Region) ForEachRect(f func(Rect)) {
+	for _, b := range r.bands {
+		for i := 0; i < len(b.xs); i += 2 {
+			f(Rect{
+				X: b.xs[i],
+				Y: b.y0,
+				W: b.xs[i+1] - b.xs[i],
+				H: b.y1 - b.y0,
+			})
+		}
+	}
+}
+
+// ContainsPoint checks whether the point passed as argument is contained in the
+// region, returning true when that's the case, false otherwise.
+//
+// Like Rect.ContainsPoint, the top and left edges are considered inside of the
+// region while the bottom and right edges are not.
+func (r Region) ContainsPoint(p Point) bool {
+	i := sort.Search(len(r.bands), func(i int) bool { return r.bands[i].y1 > p.Y })
+
+	if i == len(r.bands) || r.bands[i].y0 > p.Y {
+		return false
+	}
+
+	xs := r.bands[i].xs
+	j := sort.Search(len(xs), func(j int) bool { return xs[j] > p.X })
+	return j%2 == 1
+}
+
+// ContainsRect checks whether the rectangle passed as argument is entirely
+// contained in the region, returning true when that's the case, false
+// otherwise.
+func (r Region) ContainsRect(rect Rect) bool {
+	return rectRegion(rect).Subtract(r).Empty()
+}
+
+// Equal checks whether the region covers exactly the same area as the one
+// given as argument.
+func (r Region) Equal(r1 Region) bool {
+	if len(r.bands) != len(r1.bands) {
+		return false
+	}
+
+	for i, b := range r.bands {
+		b1 := r1.bands[i]
+
+		if b.y0 != b1.y0 || b.y1 != b1.y1 || !equalIntervals(b.xs, b1.xs) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Union returns the region covering the areas of both the receiver and the
+// region given as argument.
+func (r Region) Union(r1 Region) Region {
+	return regionOp(r, r1, func(a bool, b bool) bool { return a || b })
+}
+
+// Intersect returns the region covering the area shared by the receiver and
+// the region given as argument.
+func (r Region) Intersect(r1 Region) Region {
+	return regionOp(r, r1, func(a bool, b bool) bool { return a && b })
+}
+
+// Subtract returns the region covering the area of the receiver that is not
+// covered by the region given as argument.
+func (r Region) Subtract(r1 Region) Region {
+	return regionOp(r, r1, func(a bool, b bool) bool { return a && !b })
+}
+
+// Xor returns the region covering the areas covered by exactly one of the
+// receiver and the region given as argument.
+func (r Region) Xor(r1 Region) Region {
+	return regionOp(r, r1, func(a bool, b bool) bool { return a != b })
+}
+
+// UnionRect is equivalent to calling Union with a region made of the rectangle
+// given as argument.
+func (r Region) UnionRect(rect Rect) Region {
+	return r.Union(rectRegion(rect))
+}
+
+// IntersectRect is equivalent to calling Intersect with a region made of the
+// rectangle given as argument.
+func (r Region) IntersectRect(rect Rect) Region {
+	return r.Intersect(rectRegion(rect))
+}
+
+// SubtractRect is equivalent to calling Subtract with a region made of the
+// rectangle given as argument.
+func (r Region) SubtractRect(rect Rect) Region {
+	return r.Subtract(rectRegion(rect))
+}
+
+// XorRect is equivalent to calling Xor with a region made of the rectangle
+// given as argument.
+func (r Region) XorRect(rect Rect) Region {
+	return r.Xor(rectRegion(rect))
+}
+
+// Path satisfies the Shape interface, the returned path is made of one
+// rectangle for each of the rectangles returned by the Rects method.
+func (r Region) Path() Path {
+	n := 0
+
+	for _, b := range r.bands {
+		n += len(b.xs) / 2
+	}
+
+	p := MakePath(5 * n)
+
+	r.ForEachRect(func(rect Rect) {
+		p = AppendRect(p, rect)
+	})
+
+	return p
+}
+
+// The String method returns a human-readable representation of the region.
+func (r Region) String() string {
+	s := "region {"
+
+	r.ForEachRect(func(rect Rect) {
+		s += " " + rect.String()
+	})
+
+	return s + " }"
+}
+
+// regionOp combines two regions, op is called to determine whether an area
+// covered (or not) by each region is part of the result.
+func regionOp(r1 Region, r2 Region, op func(bool, bool) bool) Region {
+	// Collect the y coordinates where either region changes, the result is
+	// constant between each pair of consecutive values.
+	ys := make([]float64, 0, 2*(len(r1.bands)+len(r2.bands)))
+
+	for _, b := range r1.bands {
+		ys = append(ys, b.y0, b.y1)
+	}
+
+	for _, b := range r2.bands {
+		ys = append(ys, b.y0, b.y1)
+	}
+
+	sort.Float64s(ys)
+
+	var out Region
+	var i1, i2 int
+
+	for k := 1; k < len(ys); k++ {
+		y0, y1 := ys[k-1], ys[k]
+
+		if y0 == y1 {
+			continue
+		}
+
+		for i1 < len(r1.bands) && r1.bands[i1].y1 <= y0 {
+			i1++
+		}
+
+		for i2 < len(r2.bands) && r2.bands[i2].y1 <= y0 {
+			i2++
+		}
+
+		xs := combineIntervals(bandAt(r1, i1, y0), bandAt(r2, i2, y0), op)
+
+		if len(xs) == 0 {
+			continue
+		}
+
+		// Coalesce with the previous band when they touch and cover the same
+		// intervals.
+		if n := len(out.bands); n != 0 && out.bands[n-1].y1 == y0 && equalIntervals(out.bands[n-1].xs, xs) {
+			out.bands[n-1].y1 = y1
+			continue
+		}
+
+		out.bands = append(out.bands, regionBand{y0: y0, y1: y1, xs: xs})
+	}
+
+	return out
+}
+
+func bandAt(r Region, i int, y float64) []float64 {
+	if i < len(r.bands) && r.bands[i].y0 <= y {
+		return r.bands[i].xs
+	}
+	return nil
+}
+
+// combineIntervals merges two sorted lists of intervals using op to determine
+// which parts are kept.
+func combineIntervals(xs1 []float64, xs2 []float64, op func(bool, bool) bool) []float64 {
+	var out []float64
+	var i, j int
+	var in1, in2, in bool
+
+	for i < len(xs1) || j < len(xs2) {
+		var x float64
+
+		switch {
+		case j == len(xs2) || (i < len(xs1) && xs1[i] < xs2[j]):
+			x = xs1[i]
+		default:
+			x = xs2[j]
+		}
+
+		// Consume every boundary at this coordinate before evaluating the
+		// operation, so empty intervals are never produced.
+		for i < len(xs1) && xs1[i] == x {
+			in1 = !in1
+			i++
+		}
+
+		for j < len(xs2) && xs2[j] == x {
+			in2 = !in2
+			j++
+		}
+
+		if v := op(in1, in2); v != in {
+			out = append(out, x)
+			in = v
+		}
+	}
+
+	return out
+}
+
+func equalIntervals(xs1 []float64, xs2 []float64) bool {
+	if len(xs1) != len(xs2) {
+		return false
+	}
+
+	for i := range xs1 {
+		if xs1[i] != xs2[i] {
+			return false
+		}
+	}
+
+	return true
+}
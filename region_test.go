@@ -0,0 +1,163 @@
+package geom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMakeRegion(t *testing.T) {
+	tests := []struct {
+		key   string
+		in    []Rect
+		rects []Rect
+	}{
+		{
+			key:   "empty",
+			in:    nil,
+			rects: nil,
+		},
+		{
+			key:   "empty rectangle",
+			in:    []Rect{{1, 1, 0, 2}},
+			rects: nil,
+		},
+		{
+			key:   "single rectangle",
+			in:    []Rect{{1, 2, 3, 4}},
+			rects: []Rect{{1, 2, 3, 4}},
+		},
+		{
+			key:   "negative dimensions",
+			in:    []Rect{{1, 2, -1, -2}},
+			rects: []Rect{{0, 0, 1, 2}},
+		},
+		{
+			key:   "adjacent rectangles are coalesced",
+			in:    []Rect{{0, 0, 1, 1}, {1, 0, 1, 1}, {0, 1, 2, 1}},
+			rects: []Rect{{0, 0, 2, 2}},
+		},
+		{
+			key:   "diagonal rectangles",
+			in:    []Rect{{0, 0, 2, 2}, {1, 1, 2, 2}},
+			rects: []Rect{{0, 0, 2, 1}, {0, 1, 3, 1}, {1, 2, 2, 1}},
+		},
+		{
+			key:   "disjoint rectangles in the same band",
+			in:    []Rect{{3, 0, 1, 1}, {0, 0, 1, 1}},
+			rects: []Rect{{0, 0, 1, 1}, {3, 0, 1, 1}},
+		},
+	}
+
+	for _, test := range tests {
+		if rects := MakeRegion(test.in...).Rects(); !reflect.DeepEqual(rects, test.rects) {
+			t.Errorf("MakeRegion: %s: %v", test.key, rects)
+		}
+	}
+}
+
+func TestRegionOperations(t *testing.T) {
+	a := MakeRegion(Rect{0, 0, 2, 2})
+	b := MakeRegion(Rect{1, 1, 2, 2})
+
+	tests := []struct {
+		key   string
+		r     Region
+		area  float64
+		rects []Rect
+	}{
+		{"union", a.Union(b), 7, []Rect{{0, 0, 2, 1}, {0, 1, 3, 1}, {1, 2, 2, 1}}},
+		{"intersect", a.Intersect(b), 1, []Rect{{1, 1, 1, 1}}},
+		{"subtract", a.Subtract(b), 3, []Rect{{0, 0, 2, 1}, {0, 1, 1, 1}}},
+		{"xor", a.Xor(b), 6, []Rect{{0, 0, 2, 1}, {0, 1, 1, 1}, {2, 1, 1, 1}, {1, 2, 2, 1}}},
+	}
+
+	for _, test := range tests {
+		if area := test.r.Area(); area != test.area {
+			t.Errorf("%s: invalid area: %g", test.key, area)
+		}
+
+		if rects := test.r.Rects(); !reflect.DeepEqual(rects, test.rects) {
+			t.Errorf("%s: invalid rectangles: %v", test.key, rects)
+		}
+	}
+}
+
+func TestRegionRectOperations(t *testing.T) {
+	r := MakeRegion(Rect{0, 0, 4, 4})
+
+	if x := r.SubtractRect(Rect{1, 1, 2, 2}); x.Area() != 12 || x.ContainsPoint(Point{2, 2}) {
+		t.Error("invalid region with a hole:", x)
+	}
+
+	if x := r.IntersectRect(Rect{2, 2, 4, 4}); !x.Equal(MakeRegion(Rect{2, 2, 2, 2})) {
+		t.Error("invalid intersection with a rectangle:", x)
+	}
+
+	if x := r.XorRect(Rect{0, 0, 4, 4}); !x.Empty() {
+		t.Error("xor of a region with itself is not empty:", x)
+	}
+
+	if x := r.UnionRect(Rect{4, 0, 1, 4}); !x.Equal(MakeRegion(Rect{0, 0, 5, 4})) {
+		t.Error("invalid union with a rectangle:", x)
+	}
+}
+
+func TestRegionContains(t *testing.T) {
+	r := MakeRegion(Rect{0, 0, 2, 2}, Rect{4, 0, 2, 2})
+
+	tests := []struct {
+		p  Point
+		in bool
+	}{
+		{Point{0, 0}, true},
+		{Point{1, 1}, true},
+		{Point{2, 1}, false},
+		{Point{3, 1}, false},
+		{Point{4, 1}, true},
+		{Point{5, 2}, false},
+		{Point{5, -1}, false},
+	}
+
+	for _, test := range tests {
+		if r.ContainsPoint(test.p) != test.in {
+			t.Errorf("region contains %s: %t", test.p, !test.in)
+		}
+	}
+
+	if !r.ContainsRect(Rect{4, 0, 1, 1}) {
+		t.Error("region does not contain a rectangle it covers")
+	}
+
+	if r.ContainsRect(Rect{1, 0, 4, 1}) {
+		t.Error("region contains a rectangle overlapping a gap")
+	}
+}
+
+func TestRegionBounds(t *testing.T) {
+	if b := (Region{}).Bounds(); b != (Rect{}) {
+		t.Error("invalid bounds of empty region:", b)
+	}
+
+	if b := MakeRegion(Rect{1, 0, 1, 1}, Rect{0, 2, 1, 1}).Bounds(); b != (Rect{0, 0, 2, 3}) {
+		t.Error("invalid bounds of region:", b)
+	}
+}
+
+func TestRegionPath(t *testing.T) {
+	r := MakeRegion(Rect{0, 0, 2, 2}, Rect{1, 1, 2, 2})
+	p := r.Path()
+
+	if n := countMoveTo(p); n != 3 {
+		t.Error("invalid number of sub-paths in region path:", n)
+	}
+
+	if !p.Contains(Point{2.5, 1.5}, NonZero) || p.Contains(Point{2.5, 0.5}, NonZero) {
+		t.Error("region path does not cover the region")
+	}
+}
+
+func TestRegionString(t *testing.T) {
+	if s := MakeRegion(Rect{0, 0, 1, 1}).String(); s != "region { { 0, 0, 1, 1 } }" {
+		t.Error("invalid string representation of a region:", s)
+	}
+}
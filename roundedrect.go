@@ -0,0 +1,135 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+)
+
+// The CornerRadii type represents the radii of the four corners of a rounded
+// rectangle.
+type CornerRadii struct {
+	TopLeft     float64
+	TopRight    float64
+	BottomRight float64
+	BottomLeft  float64
+}
+
+// MakeCornerRadii takes a numeric value as argument that defines the radius of
+// all four corners of the returned value.
+func MakeCornerRadii(r float64) CornerRadii {
+	return CornerRadii{
+		TopLeft:     r,
+		TopRight:    r,
+		BottomRight: r,
+		BottomLeft:  r,
+	}
+}
+
+// The String method returns a human-readable representation of the corner radii.
+func (c CornerRadii) String() string {
+	return fmt.Sprintf("radii { top-left = %g, top-right = %g, bottom-right = %g, bottom-left = %g }",
+		c.TopLeft, c.TopRight, c.BottomRight, c.BottomLeft)
+}
+
+// The RoundedRect type represents a rectangle with rounded corners, each corner
+// having its own radius.
+type RoundedRect struct {
+	Rect  Rect
+	Radii CornerRadii
+}
+
+// Bounds returns the smallest rectangle that contains the rounded rectangle.
+func (r RoundedRect) Bounds() Rect {
+	return r.Rect.Abs()
+}
+
+// Normalize returns a rounded rectangle equivalent to the one it is called on,
+// where the rectangle has positive dimensions, negative radii are set to zero,
+// and radii are scaled down when the corners would overlap.
+//
+// Like in CSS, all radii are scaled by the same factor so the sum of the radii
+// of two adjacent corners never exceeds the length of the side they share.
+func (r RoundedRect) Normalize() RoundedRect {
+	r.Rect = r.Rect.Abs()
+	c := &r.Radii
+	c.TopLeft = math.Max(c.TopLeft, 0)
+	c.TopRight = math.Max(c.TopRight, 0)
+	c.BottomRight = math.Max(c.BottomRight, 0)
+	c.BottomLeft = math.Max(c.BottomLeft, 0)
+
+	f := 1.0
+	f = cornerScale(f, r.Rect.W, c.TopLeft+c.TopRight)
+	f = cornerScale(f, r.Rect.W, c.BottomLeft+c.BottomRight)
+	f = cornerScale(f, r.Rect.H, c.TopLeft+c.BottomLeft)
+	f = cornerScale(f, r.Rect.H, c.TopRight+c.BottomRight)
+
+	if f < 1 {
+		c.TopLeft *= f
+		c.TopRight *= f
+		c.BottomRight *= f
+		c.BottomLeft *= f
+	}
+
+	return r
+}
+
+func cornerScale(f float64, side float64, sum float64) float64 {
+	if sum > side {
+		f = math.Min(f, side/sum)
+	}
+	return f
+}
+
+// The String method returns a human-readable representation of the rounded
+// rectangle.
+func (r RoundedRect) String() string {
+	return fmt.Sprintf("rounded %s %s", r.Rect, r.Radii)
+}
+
+// Path satisfies the Shape interface, allowing RoundedRect values to be used
+// with programs that manipulate shapes.
+func (r RoundedRect) Path() Path {
+	return AppendRoundedRect(MakePath(10), r)
+}
+
+// AppendRoundedRect efficiently append a rounded rectangle to a Path and returns
+// the modified value.
+//
+// The rounded rectangle is normalized first, then each corner is approximated
+// by a cubic Bézier curve. The sub-path goes in the same direction as the
+// sub-paths of rectangles, and corners with a zero radius are drawn as sharp
+// corners.
+func AppendRoundedRect(path Path, r RoundedRect) Path {
+	r = r.Normalize()
+	c := r.Radii
+	x0 := r.Rect.X
+	y0 := r.Rect.Y
+	x1 := r.Rect.X + r.Rect.W
+	y1 := r.Rect.Y + r.Rect.H
+
+	path.MoveTo(Point{x0 + c.TopLeft, y0})
+	appendCorner(&path, Point{x1 - c.TopRight, y0}, Point{x1, y0}, Point{x1, y0 + c.TopRight})
+	appendCorner(&path, Point{x1, y1 - c.BottomRight}, Point{x1, y1}, Point{x1 - c.BottomRight, y1})
+	appendCorner(&path, Point{x0 + c.BottomLeft, y1}, Point{x0, y1}, Point{x0, y1 - c.BottomLeft})
+
+	// Without a radius the top-left corner is the start of the sub-path, the
+	// ClosePath element draws the last line.
+	if c.TopLeft != 0 {
+		appendCorner(&path, Point{x0, y0 + c.TopLeft}, Point{x0, y0}, Point{x0 + c.TopLeft, y0})
+	}
+
+	path.Close()
+	return path
+}
+
+// appendCorner draws a line to p0 followed by a quarter of a circle going from
+// p0 to p1 around the corner point c. The line is omitted when it would have a
+// zero length, and the curve is omitted when p0 and p1 are equal.
+func appendCorner(path *Path, p0 Point, c Point, p1 Point) {
+	if p0 != path.LastPoint() {
+		path.LineTo(p0)
+	}
+	if p0 != p1 {
+		path.CubicCurveTo(p0.Lerp(c, kappa), p1.Lerp(c, kappa), p1)
+	}
+}
@@ -0,0 +1,123 @@
+package geom
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMakeCornerRadii(t *testing.T) {
+	if c := MakeCornerRadii(2); c != (CornerRadii{2, 2, 2, 2}) {
+		t.Error("invalid corner radii:", c)
+	}
+}
+
+func TestCornerRadiiString(t *testing.T) {
+	if s := (CornerRadii{1, 2, 3, 4}).String(); s != "radii { top-left = 1, top-right = 2, bottom-right = 3, bottom-left = 4 }" {
+		t.Error(s)
+	}
+}
+
+func TestRoundedRectNormalize(t *testing.T) {
+	tests := []struct {
+		in  RoundedRect
+		out RoundedRect
+	}{
+		{
+			in:  RoundedRect{Rect{0, 0, 10, 10}, CornerRadii{1, 2, 3, 4}},
+			out: RoundedRect{Rect{0, 0, 10, 10}, CornerRadii{1, 2, 3, 4}},
+		},
+		{
+			in:  RoundedRect{Rect{10, 10, -10, -10}, CornerRadii{-1, 2, 3, 4}},
+			out: RoundedRect{Rect{0, 0, 10, 10}, CornerRadii{0, 2, 3, 4}},
+		},
+		{
+			in:  RoundedRect{Rect{0, 0, 10, 4}, MakeCornerRadii(4)},
+			out: RoundedRect{Rect{0, 0, 10, 4}, MakeCornerRadii(2)},
+		},
+		{
+			in:  RoundedRect{Rect{0, 0, 10, 10}, CornerRadii{15, 5, 0, 0}},
+			out: RoundedRect{Rect{0, 0, 10, 10}, CornerRadii{7.5, 2.5, 0, 0}},
+		},
+	}
+
+	for _, test := range tests {
+		if r := test.in.Normalize(); r != test.out {
+			t.Errorf("%s: %s != %s", test.in, r, test.out)
+		}
+	}
+}
+
+func TestRoundedRectBounds(t *testing.T) {
+	r := RoundedRect{Rect{1, 2, -3, 4}, CornerRadii{1, 0, 1, 0}}
+
+	if b := r.Bounds(); b != (Rect{-2, 2, 3, 4}) {
+		t.Error("invalid bounds:", b)
+	}
+}
+
+func TestRoundedRectString(t *testing.T) {
+	r := RoundedRect{Rect{1, 2, 3, 4}, MakeCornerRadii(1)}
+
+	if s := r.String(); s != "rounded { 1, 2, 3, 4 } radii { top-left = 1, top-right = 1, bottom-right = 1, bottom-left = 1 }" {
+		t.Error(s)
+	}
+}
+
+func TestRoundedRectPath(t *testing.T) {
+	// Without radii the path must be the same as the path of the rectangle.
+	rect := Rect{1, 2, 3, 4}
+
+	if p := (RoundedRect{Rect: rect}).Path(); !reflect.DeepEqual(p, rect.Path()) {
+		t.Error("invalid path:", p)
+	}
+
+	// Fully rounded square, the path is a circle.
+	c := Circle{Point{5, 5}, 5}
+	p := RoundedRect{Rect{0, 0, 10, 10}, MakeCornerRadii(5)}.Path()
+
+	if n := len(p.Elements); n != 6 {
+		t.Error("invalid number of elements in the path of a fully rounded square:", p)
+	}
+
+	if a := pathArea(p); !nearlyEqualTolerance(a, pathArea(c.Path()), 1e-6) {
+		t.Errorf("invalid area of a fully rounded square: %g", a)
+	}
+
+	// Mixed corners.
+	r := RoundedRect{Rect{0, 0, 10, 10}, CornerRadii{0, 2, 0, 4}}
+	p = r.Path()
+	area := 100 - (4-math.Pi)*(4+16)/4
+
+	if a := pathArea(p); !nearlyEqualTolerance(a, area, 1e-2) {
+		t.Errorf("invalid area: %g != %g", a, area)
+	}
+
+	if b := p.Bounds(); !rectsNearlyEqual(b, r.Rect) {
+		t.Error("invalid path bounds:", b)
+	}
+
+	for _, test := range []struct {
+		point    Point
+		contains bool
+	}{
+		{Point{0.1, 0.1}, true},
+		{Point{9.9, 0.1}, false},
+		{Point{9.9, 9.9}, true},
+		{Point{0.1, 9.9}, false},
+		{Point{5, 5}, true},
+	} {
+		if c := p.Contains(test.point, NonZero); c != test.contains {
+			t.Errorf("%v: %t", test.point, c)
+		}
+	}
+}
+
+func TestAppendRoundedRect(t *testing.T) {
+	r := RoundedRect{Rect{0, 0, 10, 10}, CornerRadii{1, 2, 3, 4}}
+	c := Circle{Point{1, 2}, 3}
+
+	if p := AppendRoundedRect(c.Path(), r); !reflect.DeepEqual(p, AppendPath(c.Path(), r.Path())) {
+		t.Error("invalid path:", p)
+	}
+}
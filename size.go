@@ -34,3 +34,33 @@ func (s Size) Ratio() float64 {
 func (s Size) String() string {
 	return fmt.Sprintf("[%.6g, %.6g]", s.W, s.H)
 }
+
+// MarshalText satisfies the encoding.TextMarshaler interface, the size is
+// formatted like the String method does but with the exact values of its
+// dimensions.
+func (s Size) MarshalText() ([]byte, error) {
+	return appendFloats(nil, "[", "]", s.W, s.H), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface, it parses the
+// format produced by MarshalText and String.
+func (s *Size) UnmarshalText(b []byte) error {
+	var z Size
+
+	if err := parseFloats("size", b, '[', ']', &z.W, &z.H); err != nil {
+		return err
+	}
+
+	*s = z
+	return nil
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface. Because of the
+// MarshalText method, the encoding/json package encodes sizes as strings like
+// "[1, 2]" instead of objects like {"W": 1, "H": 2}, UnmarshalJSON accepts both
+// forms so values encoded by earlier versions of the package can still be
+// decoded.
+func (s *Size) UnmarshalJSON(b []byte) error {
+	type size Size
+	return unmarshalJSON(b, s, (*size)(s))
+}
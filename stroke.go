@@ -0,0 +1,446 @@
+package geom
+
+import "math"
+
+// LineCap is an enumeration of the shapes that can be drawn at the ends of open
+// sub-paths when they are stroked.
+type LineCap int
+
+const (
+	// ButtCap ends strokes exactly at the end points of sub-paths.
+	ButtCap LineCap = iota
+
+	// RoundCap ends strokes with half circles centered on the end points of
+	// sub-paths.
+	RoundCap
+
+	// SquareCap ends strokes with half squares centered on the end points of
+	// sub-paths.
+	SquareCap
+)
+
+// LineJoin is an enumeration of the shapes that can be drawn where two
+// segments of a sub-path meet when they are stroked.
+type LineJoin int
+
+const (
+	// MiterJoin extends the outer edges of the two segments until they meet,
+	// falling back to a bevel join when the miter limit is exceeded.
+	MiterJoin LineJoin = iota
+
+	// RoundJoin connects the outer edges of the two segments with a circular
+	// arc.
+	RoundJoin
+
+	// BevelJoin connects the outer edges of the two segments with a straight
+	// line.
+	BevelJoin
+)
+
+// DefaultMiterLimit is the miter limit used when the MiterLimit field of a
+// StrokeStyle is zero.
+const DefaultMiterLimit = 4
+
+// StrokeStyle is a set of parameters controlling the outline produced when
+// stroking a path.
+type StrokeStyle struct {
+	// The width of the stroke.
+	Width float64
+
+	// The shape drawn at the ends of open sub-paths.
+	Cap LineCap
+
+	// The shape drawn where segments of sub-paths meet.
+	Join LineJoin
+
+	// The maximum ratio between the length of a miter join and the width of
+	// the stroke, DefaultMiterLimit is used when the value is zero.
+	MiterLimit float64
+
+	// The maximum distance between the outline of curves and their exact
+	// offset, one hundredth of the width is used when the value is zero.
+	Tolerance float64
+}
+
+// Stroke computes and returns the outline of the path stroked with the given
+// style. The returned path is meant to be filled using the NonZero fill rule.
+//
+// Curves are offset by cubic curves that stay within the tolerance of the
+// style, so the outline of a curved path is made of curves as well.
+func Stroke(path Path, style StrokeStyle) Path {
+	s := stroker{
+		style: style,
+		hw:    math.Abs(style.Width) / 2,
+	}
+
+	if s.hw == 0 {
+		return Path{}
+	}
+
+	if s.style.MiterLimit == 0 {
+		s.style.MiterLimit = DefaultMiterLimit
+	}
+
+	if s.style.Tolerance <= 0 {
+		s.style.Tolerance = s.hw / 50
+	}
+
+	var start Point
+	var last Point
+
+	for _, e := range path.Elements {
+		switch e.Type {
+		case MoveTo:
+			s.flush(false)
+			start, last = e.Points[0], e.Points[0]
+
+		case LineTo:
+			s.addLine(last, e.Points[0])
+			last = e.Points[0]
+
+		case QuadCurveTo:
+			s.addCubic(quadToCubic([3]Point{last, e.Points[0], e.Points[1]}), 0, false)
+			last = e.Points[1]
+
+		case CubicCurveTo:
+			s.addCubic([4]Point{last, e.Points[0], e.Points[1], e.Points[2]}, 0, false)
+			last = e.Points[2]
+
+		case ClosePath:
+			s.addLine(last, start)
+			s.flush(true)
+			last = start
+		}
+	}
+
+	s.flush(false)
+	return s.out
+}
+
+// strokeSegment represents a line or a cubic curve of a sub-path being
+// stroked, lines only use the first and last points.
+type strokeSegment struct {
+	p      [4]Point
+	line   bool
+	smooth bool
+}
+
+func (seg strokeSegment) startTangent() Point {
+	for _, p := range seg.p[1:] {
+		if p != seg.p[0] {
+			return p.Sub(seg.p[0]).Normalize()
+		}
+	}
+	return Point{}
+}
+
+func (seg strokeSegment) endTangent() Point {
+	for i := 2; i >= 0; i-- {
+		if p := seg.p[i]; p != seg.p[3] {
+			return seg.p[3].Sub(p).Normalize()
+		}
+	}
+	return Point{}
+}
+
+type stroker struct {
+	style StrokeStyle
+	hw    float64
+	segs  []strokeSegment
+	dot   bool
+	dotAt Point
+	out   Path
+}
+
+func (s *stroker) addLine(p0 Point, p1 Point) {
+	if p0 == p1 {
+		s.addDot(p0)
+		return
+	}
+	s.segs = append(s.segs, strokeSegment{
+		p:    [4]Point{p0, p0, p1, p1},
+		line: true,
+	})
+}
+
+// addCubic appends the cubic curve c to the list of segments, subdividing it
+// until each piece can be offset within the tolerance. Pieces following the
+// first one are flagged as smooth since they are joined to their predecessor
+// without a visible join.
+func (s *stroker) addCubic(c [4]Point, depth int, smooth bool) {
+	if c[0] == c[1] && c[0] == c[2] && c[0] == c[3] {
+		s.addDot(c[0])
+		return
+	}
+
+	if depth < maxSubdivisions && !s.offsetable(c) {
+		a, b := splitCubic(c, 0.5)
+		s.addCubic(a, depth+1, smooth)
+		s.addCubic(b, depth+1, true)
+		return
+	}
+
+	s.segs = append(s.segs, strokeSegment{
+		p:      c,
+		smooth: smooth,
+	})
+}
+
+func (s *stroker) addDot(p Point) {
+	if !s.dot {
+		s.dot, s.dotAt = true, p
+	}
+}
+
+// offsetable checks whether the offset curves on both sides of c computed by
+// offsetCubic are within the tolerance of the exact offsets.
+func (s *stroker) offsetable(c [4]Point) bool {
+	seg := strokeSegment{p: c}
+	t0 := seg.startTangent()
+	t1 := seg.endTangent()
+
+	// Curves turning by more than a small angle are always subdivided, the
+	// offset approximation degrades quickly with the curvature.
+	if t0.Dot(t1) < math.Cos(math.Pi/8) {
+		return false
+	}
+
+	for _, d := range [...]float64{s.hw, -s.hw} {
+		o := offsetCubic(c, t0, t1, d)
+
+		for _, t := range [...]float64{0.25, 0.5, 0.75} {
+			n := cubicDerivative(c, t).Normalize().Perp()
+
+			if n.Zero() {
+				return false
+			}
+
+			exact := cubicPoint(c[0], c[1], c[2], c[3], t).Add(n.Scale(d))
+			approx := cubicPoint(o[0], o[1], o[2], o[3], t)
+
+			if exact.Distance(approx) > s.style.Tolerance {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// offsetCubic approximates the offset of c by distance d, where t0 and t1 are
+// the tangents at the start and end of the curve.
+func offsetCubic(c [4]Point, t0 Point, t1 Point, d float64) [4]Point {
+	n0 := t0.Perp().Scale(d)
+	n1 := t1.Perp().Scale(d)
+	return [4]Point{
+		c[0].Add(n0),
+		c[1].Add(n0),
+		c[2].Add(n1),
+		c[3].Add(n1),
+	}
+}
+
+// flush generates the outline of the current sub-path, which is closed if the
+// argument is true, and resets the stroker to start a new sub-path.
+func (s *stroker) flush(closed bool) {
+	segs := s.segs
+	dot, dotAt := s.dot, s.dotAt
+	s.segs, s.dot = s.segs[:0], false
+
+	if len(segs) == 0 {
+		if dot {
+			s.strokeDot(dotAt)
+		}
+		return
+	}
+
+	if closed {
+		s.contour(segs, s.hw, true)
+		s.reverseLastSubpath(s.contour(segs, -s.hw, true))
+		return
+	}
+
+	first, last := segs[0], segs[len(segs)-1]
+	s.contour(segs, s.hw, false)
+	s.cap(last.p[3], last.endTangent())
+	s.out.Elements = append(s.out.Elements, s.reversedContour(segs)...)
+	s.cap(first.p[0], first.startTangent().Neg())
+	s.out.Close()
+}
+
+// contour appends to the output the offset of the segments by distance d,
+// returning the index of the first element that was appended.
+func (s *stroker) contour(segs []strokeSegment, d float64, closed bool) int {
+	i := len(s.out.Elements)
+	s.out.MoveTo(segs[0].p[0].Add(segs[0].startTangent().Perp().Scale(d)))
+
+	for j, seg := range segs {
+		if j != 0 {
+			s.join(segs[j-1], seg, d)
+		}
+		s.offset(seg, d)
+	}
+
+	if closed {
+		s.join(segs[len(segs)-1], segs[0], d)
+		s.out.Close()
+	}
+
+	return i
+}
+
+// reversedContour returns the elements of the offset of the segments on the
+// right side, in reverse order. The leading MoveTo element is removed so the
+// elements can continue the current sub-path.
+func (s *stroker) reversedContour(segs []strokeSegment) []PathElement {
+	out := s.out
+	s.out = Path{}
+	s.contour(segs, -s.hw, false)
+	elems := reverseSubpath(s.out.Elements)
+	s.out = out
+	return elems[1:]
+}
+
+func (s *stroker) reverseLastSubpath(i int) {
+	n := len(s.out.Elements)
+	copy(s.out.Elements[i:], reverseSubpath(s.out.Elements[i:n-1]))
+}
+
+func (s *stroker) offset(seg strokeSegment, d float64) {
+	t0 := seg.startTangent()
+	t1 := seg.endTangent()
+
+	if seg.line {
+		s.out.LineTo(seg.p[3].Add(t1.Perp().Scale(d)))
+		return
+	}
+
+	o := offsetCubic(seg.p, t0, t1, d)
+	s.out.CubicCurveTo(o[1], o[2], o[3])
+}
+
+// join appends to the output the join between the segments a and b, on the
+// side of the stroke at distance d.
+func (s *stroker) join(a strokeSegment, b strokeSegment, d float64) {
+	v := a.p[3]
+	ta := a.endTangent()
+	tb := b.startTangent()
+	na := ta.Perp()
+	nb := tb.Perp()
+	pa := v.Add(na.Scale(d))
+	pb := v.Add(nb.Scale(d))
+
+	if pa == pb {
+		return
+	}
+
+	cross := ta.Cross(tb)
+	dot := ta.Dot(tb)
+
+	if math.Abs(cross) < 1e-9 && dot > 0 {
+		s.out.LineTo(pb)
+		return
+	}
+
+	// On the inner side of the join the outline goes through the vertex,
+	// which keeps the filled area correct even when segments are shorter
+	// than the width of the stroke.
+	if d*cross > 0 {
+		s.out.LineTo(v)
+		s.out.LineTo(pb)
+		return
+	}
+
+	join := s.style.Join
+
+	if b.smooth {
+		join = RoundJoin
+	}
+
+	switch join {
+	case RoundJoin:
+		delta := ta.AngleTo(tb)
+
+		if math.Abs(cross) < 1e-9 {
+			delta = -math.Copysign(math.Pi, d)
+		}
+
+		appendEllipseSegments(&s.out, v, Size{s.hw, s.hw}, 0, pa.Sub(v).Angle(), delta, pb)
+
+	case MiterJoin:
+		if c := na.Dot(nb); c > -1 && math.Sqrt(2/(1+c)) <= s.style.MiterLimit {
+			s.out.LineTo(v.Add(na.Add(nb).Scale(d / (1 + c))))
+		}
+		s.out.LineTo(pb)
+
+	default:
+		s.out.LineTo(pb)
+	}
+}
+
+// cap appends to the output the cap at point p for a stroke going in the
+// direction t, starting on the left side of the stroke and ending on the right
+// side.
+func (s *stroker) cap(p Point, t Point) {
+	n := t.Perp().Scale(s.hw)
+	e := t.Scale(s.hw)
+
+	switch s.style.Cap {
+	case RoundCap:
+		appendEllipseSegments(&s.out, p, Size{s.hw, s.hw}, 0, n.Angle(), -math.Pi, p.Sub(n))
+
+	case SquareCap:
+		s.out.LineTo(p.Add(n).Add(e))
+		s.out.LineTo(p.Sub(n).Add(e))
+		s.out.LineTo(p.Sub(n))
+
+	default:
+		s.out.LineTo(p.Sub(n))
+	}
+}
+
+// strokeDot appends to the output the shape drawn by the caps of a sub-path
+// which has a length of zero.
+func (s *stroker) strokeDot(p Point) {
+	switch s.style.Cap {
+	case RoundCap:
+		s.out.MoveTo(Point{p.X + s.hw, p.Y})
+		appendEllipseSegments(&s.out, p, Size{s.hw, s.hw}, 0, 0, 2*math.Pi, Point{p.X + s.hw, p.Y})
+		s.out.Close()
+
+	case SquareCap:
+		s.out = AppendRect(s.out, Rect{p.X - s.hw, p.Y - s.hw, 2 * s.hw, 2 * s.hw})
+	}
+}
+
+// reverseSubpath returns a sub-path drawing the same shape as the elements
+// given as argument but in the opposite direction. The elements must start
+// with a MoveTo and contain no other MoveTo or ClosePath elements.
+func reverseSubpath(elems []PathElement) []PathElement {
+	p := Path{Elements: elems}
+	r := MakePath(len(elems))
+
+	if len(elems) == 0 {
+		return r.Elements
+	}
+
+	r.MoveTo(p.LastPoint())
+
+	for i := len(elems) - 1; i > 0; i-- {
+		e := elems[i]
+		prev := p.lastPointAt(i - 1)
+
+		switch e.Type {
+		case LineTo:
+			r.LineTo(prev)
+
+		case QuadCurveTo:
+			r.QuadCurveTo(e.Points[0], prev)
+
+		case CubicCurveTo:
+			r.CubicCurveTo(e.Points[1], e.Points[0], prev)
+		}
+	}
+
+	return r.Elements
+}
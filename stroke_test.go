@@ -0,0 +1,177 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStrokeZeroWidth(t *testing.T) {
+	p := Rect{0, 0, 1, 1}.Path()
+
+	if s := Stroke(p, StrokeStyle{}); !s.Empty() {
+		t.Error("stroking with a zero width must produce an empty path:", s)
+	}
+}
+
+func TestStrokeCaps(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.LineTo(Point{10, 0})
+
+	tests := []struct {
+		cap    LineCap
+		bounds Rect
+	}{
+		{ButtCap, Rect{0, -1, 10, 2}},
+		{RoundCap, Rect{-1, -1, 12, 2}},
+		{SquareCap, Rect{-1, -1, 12, 2}},
+	}
+
+	for _, test := range tests {
+		s := Stroke(p, StrokeStyle{Width: 2, Cap: test.cap})
+
+		if b := s.Bounds(); !rectsNearlyEqual(b, test.bounds) {
+			t.Errorf("invalid bounds of stroke with cap %d: %s", test.cap, b)
+		}
+
+		if !s.Contains(Point{5, 0.9}, NonZero) || s.Contains(Point{5, 1.1}, NonZero) {
+			t.Errorf("invalid body of stroke with cap %d", test.cap)
+		}
+	}
+
+	s := Stroke(p, StrokeStyle{Width: 2, Cap: RoundCap})
+
+	if !s.Contains(Point{-0.6, 0.6}, NonZero) || s.Contains(Point{-0.8, 0.8}, NonZero) {
+		t.Error("invalid round cap")
+	}
+
+	s = Stroke(p, StrokeStyle{Width: 2, Cap: SquareCap})
+
+	if !s.Contains(Point{-0.9, 0.9}, NonZero) {
+		t.Error("invalid square cap")
+	}
+}
+
+func TestStrokeJoins(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.LineTo(Point{10, 0})
+	p.LineTo(Point{10, 10})
+
+	tests := []struct {
+		join LineJoin
+		in   []Point
+		out  []Point
+	}{
+		{MiterJoin, []Point{{10.9, -0.9}, {9, 0.9}}, []Point{{11.1, -1.1}, {8.9, 1.1}}},
+		{BevelJoin, []Point{{10.4, -0.4}, {9, 0.9}}, []Point{{10.6, -0.6}, {8.9, 1.1}}},
+		{RoundJoin, []Point{{10.6, -0.6}, {9, 0.9}}, []Point{{10.8, -0.8}, {8.9, 1.1}}},
+	}
+
+	for _, test := range tests {
+		s := Stroke(p, StrokeStyle{Width: 2, Join: test.join})
+
+		for _, pt := range test.in {
+			if !s.Contains(pt, NonZero) {
+				t.Errorf("stroke with join %d does not contain %s", test.join, pt)
+			}
+		}
+
+		for _, pt := range test.out {
+			if s.Contains(pt, NonZero) {
+				t.Errorf("stroke with join %d contains %s", test.join, pt)
+			}
+		}
+	}
+}
+
+func TestStrokeMiterLimit(t *testing.T) {
+	// A sharp angle, the miter would extend far beyond the vertex.
+	p := Path{}
+	p.MoveTo(Point{0, 0})
+	p.LineTo(Point{10, 0})
+	p.LineTo(Point{0, 1})
+
+	s := Stroke(p, StrokeStyle{Width: 2, Join: MiterJoin})
+
+	if b := s.Bounds(); b.X+b.W > 12 {
+		t.Error("miter limit was not applied:", b)
+	}
+
+	s = Stroke(p, StrokeStyle{Width: 2, Join: MiterJoin, MiterLimit: 100})
+
+	if b := s.Bounds(); b.X+b.W < 12 {
+		t.Error("miter was not drawn with a high miter limit:", b)
+	}
+}
+
+func TestStrokeClosed(t *testing.T) {
+	p := Rect{0, 0, 10, 10}.Path()
+	s := Stroke(p, StrokeStyle{Width: 2})
+
+	if b := s.Bounds(); !rectsNearlyEqual(b, Rect{-1, -1, 12, 12}) {
+		t.Error("invalid bounds of closed stroke:", b)
+	}
+
+	for _, pt := range []Point{{-0.9, -0.9}, {5, 0.9}, {10.9, 5}, {5, -0.9}} {
+		if !s.Contains(pt, NonZero) {
+			t.Error("closed stroke does not contain", pt)
+		}
+	}
+
+	for _, pt := range []Point{{5, 5}, {5, 1.1}, {5, -1.1}} {
+		if s.Contains(pt, NonZero) {
+			t.Error("closed stroke contains", pt)
+		}
+	}
+}
+
+func TestStrokeCurve(t *testing.T) {
+	// Quarter circle of radius 10 centered at the origin.
+	const k = 0.5522847498 * 10
+	p := Path{}
+	p.MoveTo(Point{10, 0})
+	p.CubicCurveTo(Point{10, k}, Point{k, 10}, Point{0, 10})
+
+	s := Stroke(p, StrokeStyle{Width: 2})
+
+	for _, e := range s.Elements {
+		if e.Type == QuadCurveTo {
+			t.Error("stroke contains unexpected quadratic curves")
+		}
+	}
+
+	for i := 1; i < 10; i++ {
+		a := math.Pi / 2 * float64(i) / 10
+
+		for _, r := range []float64{9.05, 10, 10.95} {
+			if pt := MakePolar(r, a); !s.Contains(pt, NonZero) {
+				t.Error("curve stroke does not contain", pt)
+			}
+		}
+
+		for _, r := range []float64{8.95, 11.05} {
+			if pt := MakePolar(r, a); s.Contains(pt, NonZero) {
+				t.Error("curve stroke contains", pt)
+			}
+		}
+	}
+}
+
+func TestStrokeDot(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{1, 1})
+	p.LineTo(Point{1, 1})
+
+	if s := Stroke(p, StrokeStyle{Width: 2}); !s.Empty() {
+		t.Error("zero-length sub-path with butt caps must not be drawn:", s)
+	}
+
+	if s := Stroke(p, StrokeStyle{Width: 2, Cap: RoundCap}); !rectsNearlyEqual(s.Bounds(), Rect{0, 0, 2, 2}) {
+		t.Error("invalid zero-length sub-path with round caps:", s.Bounds())
+	}
+
+	if s := Stroke(p, StrokeStyle{Width: 2, Cap: SquareCap}); s.Bounds() != (Rect{0, 0, 2, 2}) {
+		t.Error("invalid zero-length sub-path with square caps:", s.Bounds())
+	}
+}
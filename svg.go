@@ -0,0 +1,414 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SVGPathError is the error type returned by ParseSVGPath when the path data
+// is malformed.
+type SVGPathError struct {
+	// The byte offset in the path data where the error was detected.
+	Offset int
+
+	// A description of the error.
+	Reason string
+}
+
+// The Error method satisfies the error interface.
+func (e *SVGPathError) Error() string {
+	return fmt.Sprintf("geom: invalid svg path data at offset %d: %s", e.Offset, e.Reason)
+}
+
+// ParseSVGPath parses a string containing SVG path data (the value of the `d`
+// attribute of a `path` element) and returns the corresponding Path value.
+//
+// The full SVG path grammar is supported, including relative commands, smooth
+// curves and elliptical arcs, which are converted to cubic curves.
+func ParseSVGPath(d string) (Path, error) {
+	p := svgParser{data: d}
+	return p.parse()
+}
+
+// FormatSVGPath formats the path as SVG path data and returns the result.
+//
+// The precision argument is the maximum number of digits written after the
+// decimal point of each coordinate, a negative value uses the smallest number
+// of digits necessary to represent every value exactly.
+//
+// The output is made compact by using absolute commands only, omitting
+// repeated command letters and any separators that aren't required.
+func FormatSVGPath(p Path, precision int) string {
+	f := svgFormatter{precision: precision}
+
+	for _, e := range p.Elements {
+		switch e.Type {
+		case MoveTo:
+			f.command('M', e.Points[:1])
+
+		case LineTo:
+			f.command('L', e.Points[:1])
+
+		case QuadCurveTo:
+			f.command('Q', e.Points[:2])
+
+		case CubicCurveTo:
+			f.command('C', e.Points[:3])
+
+		case ClosePath:
+			f.command('Z', nil)
+		}
+	}
+
+	return string(f.buf)
+}
+
+type svgFormatter struct {
+	buf       []byte
+	precision int
+	last      byte
+	dot       bool
+}
+
+func (f *svgFormatter) command(c byte, pts []Point) {
+	// Repeated commands can be omitted, except for MoveTo which would be
+	// interpreted as LineTo when repeated.
+	if c != f.last || c == 'M' || c == 'Z' {
+		f.buf = append(f.buf, c)
+		f.last = c
+		f.dot = false
+	}
+
+	for _, pt := range pts {
+		f.number(pt.X)
+		f.number(pt.Y)
+	}
+}
+
+func (f *svgFormatter) number(v float64) {
+	s := strconv.FormatFloat(v, 'f', f.precision, 64)
+
+	if strings.IndexByte(s, '.') >= 0 {
+		s = strings.TrimRight(s, "0")
+		s = strings.TrimSuffix(s, ".")
+	}
+
+	switch {
+	case s == "-0":
+		s = "0"
+	case strings.HasPrefix(s, "0."):
+		s = s[1:]
+	case strings.HasPrefix(s, "-0."):
+		s = "-" + s[2:]
+	}
+
+	// A separator is only needed when the new number could be confused as
+	// being part of the previous one.
+	if n := len(f.buf); n != 0 && !isSVGCommand(f.buf[n-1]) {
+		if s[0] != '-' && !(s[0] == '.' && f.dot) {
+			f.buf = append(f.buf, ' ')
+		}
+	}
+
+	f.buf = append(f.buf, s...)
+	f.dot = strings.IndexByte(s, '.') >= 0
+}
+
+func isSVGCommand(c byte) bool {
+	return strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0
+}
+
+type svgParser struct {
+	data string
+	off  int
+	path Path
+
+	start   Point
+	current Point
+	control Point
+	last    byte
+	closed  bool
+}
+
+func (p *svgParser) parse() (Path, error) {
+	p.skipSpaces()
+
+	if p.off < len(p.data) && p.data[p.off] != 'M' && p.data[p.off] != 'm' {
+		return Path{}, p.errorf("path data must start with a move-to command")
+	}
+
+	for p.skipSpaces(); p.off < len(p.data); p.skipSpaces() {
+		c := p.data[p.off]
+
+		if !isSVGCommand(c) {
+			return Path{}, p.errorf("unexpected character %q", c)
+		}
+
+		p.off++
+
+		if err := p.parseCommand(c); err != nil {
+			return Path{}, err
+		}
+	}
+
+	return p.path, nil
+}
+
+func (p *svgParser) parseCommand(c byte) error {
+	if c == 'Z' || c == 'z' {
+		p.path.Close()
+		p.current = p.start
+		p.closed = true
+		p.last = c
+		return nil
+	}
+
+	n := svgArgCount(c)
+	p.skipSpaces()
+
+	// Commands may be followed by multiple sets of arguments, which repeat the
+	// command implicitly.
+	for first := true; first || p.hasNumber(); first = false {
+		var args [7]float64
+
+		for i := 0; i < n; i++ {
+			var err error
+
+			if i != 0 {
+				p.skipSeparator()
+			}
+
+			if (c == 'A' || c == 'a') && (i == 3 || i == 4) {
+				args[i], err = p.parseFlag()
+			} else {
+				args[i], err = p.parseNumber()
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		p.apply(c, args[:n])
+		comma := p.skipSeparator()
+
+		// Implicit repetitions of move-to commands are line-to commands.
+		switch c {
+		case 'M':
+			c = 'L'
+		case 'm':
+			c = 'l'
+		}
+
+		// A comma may only separate two sets of arguments.
+		if comma && !p.hasNumber() {
+			return p.errorf("expected a number after ','")
+		}
+	}
+
+	return nil
+}
+
+func svgArgCount(c byte) int {
+	switch c {
+	case 'H', 'h', 'V', 'v':
+		return 1
+	case 'M', 'm', 'L', 'l', 'T', 't':
+		return 2
+	case 'S', 's', 'Q', 'q':
+		return 4
+	case 'C', 'c':
+		return 6
+	default: // 'A', 'a'
+		return 7
+	}
+}
+
+func (p *svgParser) apply(c byte, args []float64) {
+	rel := c >= 'a'
+	abs := func(x float64, y float64) Point {
+		if rel {
+			return Point{p.current.X + x, p.current.Y + y}
+		}
+		return Point{x, y}
+	}
+
+	if c != 'M' && c != 'm' && p.closed {
+		// After closing a sub-path the drawing restarts from the first
+		// point of the closed sub-path.
+		p.path.MoveTo(p.start)
+	}
+
+	p.closed = false
+	ctrl := p.current
+
+	switch c {
+	case 'M', 'm':
+		pt := abs(args[0], args[1])
+		p.path.MoveTo(pt)
+		p.start, p.current = pt, pt
+
+	case 'L', 'l':
+		p.current = abs(args[0], args[1])
+		p.path.LineTo(p.current)
+
+	case 'H', 'h':
+		pt := Point{args[0], p.current.Y}
+		if rel {
+			pt.X += p.current.X
+		}
+		p.current = pt
+		p.path.LineTo(pt)
+
+	case 'V', 'v':
+		pt := Point{p.current.X, args[0]}
+		if rel {
+			pt.Y += p.current.Y
+		}
+		p.current = pt
+		p.path.LineTo(pt)
+
+	case 'C', 'c':
+		c1, c2, pt := abs(args[0], args[1]), abs(args[2], args[3]), abs(args[4], args[5])
+		p.path.CubicCurveTo(c1, c2, pt)
+		p.current, ctrl = pt, c2
+
+	case 'S', 's':
+		c1 := p.current
+		if p.last == 'C' || p.last == 'c' || p.last == 'S' || p.last == 's' {
+			c1 = p.current.Add(p.current.Sub(p.control))
+		}
+		c2, pt := abs(args[0], args[1]), abs(args[2], args[3])
+		p.path.CubicCurveTo(c1, c2, pt)
+		p.current, ctrl = pt, c2
+
+	case 'Q', 'q':
+		c1, pt := abs(args[0], args[1]), abs(args[2], args[3])
+		p.path.QuadCurveTo(c1, pt)
+		p.current, ctrl = pt, c1
+
+	case 'T', 't':
+		c1 := p.current
+		if p.last == 'Q' || p.last == 'q' || p.last == 'T' || p.last == 't' {
+			c1 = p.current.Add(p.current.Sub(p.control))
+		}
+		pt := abs(args[0], args[1])
+		p.path.QuadCurveTo(c1, pt)
+		p.current, ctrl = pt, c1
+
+	case 'A', 'a':
+		pt := abs(args[5], args[6])
+		appendArc(&p.path, p.current, Size{args[0], args[1]}, args[2]*math.Pi/180, args[3] != 0, args[4] != 0, pt)
+		p.current = pt
+	}
+
+	p.control = ctrl
+	p.last = c
+}
+
+func (p *svgParser) errorf(format string, args ...interface{}) error {
+	return &SVGPathError{
+		Offset: p.off,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *svgParser) skipSpaces() {
+	for p.off < len(p.data) && isSVGSpace(p.data[p.off]) {
+		p.off++
+	}
+}
+
+// skipSeparator skips the spaces and the optional comma between two numbers,
+// and returns true if a comma was found.
+func (p *svgParser) skipSeparator() bool {
+	p.skipSpaces()
+
+	if p.off < len(p.data) && p.data[p.off] == ',' {
+		p.off++
+		p.skipSpaces()
+		return true
+	}
+
+	return false
+}
+
+func (p *svgParser) hasNumber() bool {
+	if p.off < len(p.data) {
+		switch c := p.data[p.off]; {
+		case c >= '0' && c <= '9', c == '-', c == '+', c == '.':
+			return true
+		}
+	}
+	return false
+}
+
+func (p *svgParser) parseFlag() (float64, error) {
+	if p.off < len(p.data) {
+		switch p.data[p.off] {
+		case '0':
+			p.off++
+			return 0, nil
+		case '1':
+			p.off++
+			return 1, nil
+		}
+	}
+	return 0, p.errorf("expected arc flag")
+}
+
+func (p *svgParser) parseNumber() (float64, error) {
+	i := p.off
+
+	if i < len(p.data) && (p.data[i] == '-' || p.data[i] == '+') {
+		i++
+	}
+
+	digits := 0
+
+	for ; i < len(p.data) && isDigit(p.data[i]); i++ {
+		digits++
+	}
+
+	if i < len(p.data) && p.data[i] == '.' {
+		for i++; i < len(p.data) && isDigit(p.data[i]); i++ {
+			digits++
+		}
+	}
+
+	if digits == 0 {
+		return 0, p.errorf("expected number")
+	}
+
+	if i < len(p.data) && (p.data[i] == 'e' || p.data[i] == 'E') {
+		j := i + 1
+
+		if j < len(p.data) && (p.data[j] == '-' || p.data[j] == '+') {
+			j++
+		}
+
+		if j < len(p.data) && isDigit(p.data[j]) {
+			for i = j; i < len(p.data) && isDigit(p.data[i]); i++ {
+			}
+		}
+	}
+
+	v, err := strconv.ParseFloat(p.data[p.off:i], 64)
+
+	if err != nil {
+		return 0, p.errorf("invalid number %q", p.data[p.off:i])
+	}
+
+	p.off = i
+	return v, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isSVGSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,225 @@
+package geom
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseSVGPath(t *testing.T) {
+	tests := []struct {
+		key  string
+		data string
+		path []PathElement
+	}{
+		{
+			key:  "empty",
+			data: "",
+			path: nil,
+		},
+		{
+			key:  "absolute lines",
+			data: "M 1 2 L 3 4 H 5 V 6 Z",
+			path: []PathElement{
+				{Type: MoveTo, Points: [...]Point{{1, 2}, {}, {}}},
+				{Type: LineTo, Points: [...]Point{{3, 4}, {}, {}}},
+				{Type: LineTo, Points: [...]Point{{5, 4}, {}, {}}},
+				{Type: LineTo, Points: [...]Point{{5, 6}, {}, {}}},
+				{Type: ClosePath},
+			},
+		},
+		{
+			key:  "relative lines with implicit repeats",
+			data: "m1,2 1,1 1-1h-2v.5.5z",
+			path: []PathElement{
+				{Type: MoveTo, Points: [...]Point{{1, 2}, {}, {}}},
+				{Type: LineTo, Points: [...]Point{{2, 3}, {}, {}}},
+				{Type: LineTo, Points: [...]Point{{3, 2}, {}, {}}},
+				{Type: LineTo, Points: [...]Point{{1, 2}, {}, {}}},
+				{Type: LineTo, Points: [...]Point{{1, 2.5}, {}, {}}},
+				{Type: LineTo, Points: [...]Point{{1, 3}, {}, {}}},
+				{Type: ClosePath},
+			},
+		},
+		{
+			key:  "drawing after close-path restarts from the sub-path start",
+			data: "M1 1L2 2Zl1 0",
+			path: []PathElement{
+				{Type: MoveTo, Points: [...]Point{{1, 1}, {}, {}}},
+				{Type: LineTo, Points: [...]Point{{2, 2}, {}, {}}},
+				{Type: ClosePath},
+				{Type: MoveTo, Points: [...]Point{{1, 1}, {}, {}}},
+				{Type: LineTo, Points: [...]Point{{2, 1}, {}, {}}},
+			},
+		},
+		{
+			key:  "cubic curves with smooth continuation",
+			data: "M0 0C0 1 1 1 1 0s1-1 1 0",
+			path: []PathElement{
+				{Type: MoveTo, Points: [...]Point{{0, 0}, {}, {}}},
+				{Type: CubicCurveTo, Points: [...]Point{{0, 1}, {1, 1}, {1, 0}}},
+				{Type: CubicCurveTo, Points: [...]Point{{1, -1}, {2, -1}, {2, 0}}},
+			},
+		},
+		{
+			key:  "smooth cubic curve without previous cubic curve",
+			data: "M0 0S1 1 2 0",
+			path: []PathElement{
+				{Type: MoveTo, Points: [...]Point{{0, 0}, {}, {}}},
+				{Type: CubicCurveTo, Points: [...]Point{{0, 0}, {1, 1}, {2, 0}}},
+			},
+		},
+		{
+			key:  "quadratic curves with smooth continuation",
+			data: "M0 0Q1 1 2 0T4 0t2 0",
+			path: []PathElement{
+				{Type: MoveTo, Points: [...]Point{{0, 0}, {}, {}}},
+				{Type: QuadCurveTo, Points: [...]Point{{1, 1}, {2, 0}, {}}},
+				{Type: QuadCurveTo, Points: [...]Point{{3, -1}, {4, 0}, {}}},
+				{Type: QuadCurveTo, Points: [...]Point{{5, 1}, {6, 0}, {}}},
+			},
+		},
+		{
+			key:  "exponents",
+			data: "M1e1-1E-1",
+			path: []PathElement{
+				{Type: MoveTo, Points: [...]Point{{10, -0.1}, {}, {}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		p, err := ParseSVGPath(test.data)
+
+		if err != nil {
+			t.Errorf("ParseSVGPath: %s: %s", test.key, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(p.Elements, test.path) {
+			t.Errorf("ParseSVGPath: %s: %#v", test.key, p.Elements)
+		}
+	}
+}
+
+func TestParseSVGPathArc(t *testing.T) {
+	// Half circle of radius 1 from (0, 0) to (2, 0), with compact flags.
+	p, err := ParseSVGPath("M0 0A1 1 0 012 0")
+
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if n := len(p.Elements); n != 3 {
+		t.Error("invalid number of elements in the arc path:", n)
+		return
+	}
+
+	if pt := p.LastPoint(); pt != (Point{2, 0}) {
+		t.Error("arc does not end at the expected point:", pt)
+	}
+
+	// The sweep flag is set so the arc goes through the positive angle
+	// direction, which passes by (1, -1) when y points down.
+	if b := p.Bounds(); !nearlyEqualTolerance(b.Y, -1, 1e-3) || !nearlyEqualTolerance(b.H, 1, 1e-3) {
+		t.Error("invalid bounds of the arc path:", b)
+	}
+
+	for _, e := range p.Elements[1:] {
+		for _, pt := range e.Points {
+			if d := pt.Distance(Point{1, 0}); d > 1.4 {
+				t.Error("arc control point too far from the center:", pt)
+			}
+		}
+	}
+}
+
+func TestParseSVGPathArcRadiiScaled(t *testing.T) {
+	p, err := ParseSVGPath("M0 0a0.1 0.1 0 0 0 2 0")
+
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if b := p.Bounds(); !nearlyEqualTolerance(b.H, 1, 1e-3) {
+		t.Error("arc radii were not scaled up to reach the end point:", b)
+	}
+}
+
+func TestParseSVGPathErrors(t *testing.T) {
+	tests := []struct {
+		data   string
+		offset int
+	}{
+		{"L1 1", 0},
+		{"M1", 2},
+		{"M1 1 X", 5},
+		{"M1 1 L", 6},
+		{"M0 0 A1 1 0 2 0 1 1", 12},
+		{"M0 0 Z 1", 7},
+		{"M0,0L10,10,", 11},
+		{"M0,0L10,10, Z", 12},
+		{"M0 0,", 5},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSVGPath(test.data)
+
+		if e, ok := err.(*SVGPathError); !ok {
+			t.Errorf("ParseSVGPath(%q): expected an error but got %v", test.data, err)
+		} else if e.Offset != test.offset {
+			t.Errorf("ParseSVGPath(%q): invalid error offset: %s", test.data, e)
+		}
+	}
+}
+
+func TestFormatSVGPath(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{0, 0.5})
+	p.LineTo(Point{1, -0.25})
+	p.LineTo(Point{2, 0})
+	p.QuadCurveTo(Point{1.5, 0.5}, Point{3, 3})
+	p.CubicCurveTo(Point{0.126, 0.5}, Point{1, 1}, Point{math.Pi, 2})
+	p.Close()
+
+	tests := []struct {
+		precision int
+		data      string
+	}{
+		{-1, "M0 .5L1-.25 2 0Q1.5.5 3 3C.126.5 1 1 3.141592653589793 2Z"},
+		{2, "M0 .5L1-.25 2 0Q1.5.5 3 3C.13.5 1 1 3.14 2Z"},
+		{0, "M0 0L1 0 2 0Q2 0 3 3C0 0 1 1 3 2Z"},
+	}
+
+	for _, test := range tests {
+		if s := FormatSVGPath(p, test.precision); s != test.data {
+			t.Errorf("FormatSVGPath(%d): %s", test.precision, s)
+		}
+	}
+}
+
+func TestSVGPathRoundTrip(t *testing.T) {
+	p1, err := ParseSVGPath("M10 20c1.5-2 3.25 4 5 6q1 2 3 4l-7.5.25zM1 1h2v2h-2z")
+
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	p2, err := ParseSVGPath(FormatSVGPath(p1, -1))
+
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(p1, p2) {
+		t.Errorf("path changed after formatting and parsing:\n%#v\n%#v", p1, p2)
+	}
+}
+
+func nearlyEqualTolerance(a float64, b float64, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
@@ -0,0 +1,194 @@
+package geom
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// TextError is the error type returned by the UnmarshalText methods when the
+// text representation of a value is malformed.
+type TextError struct {
+	// The name of the type that was being parsed.
+	Type string
+
+	// The byte offset in the text where the error was detected.
+	Offset int
+
+	// A description of the error.
+	Reason string
+}
+
+// The Error method satisfies the error interface.
+func (e *TextError) Error() string {
+	return fmt.Sprintf("geom: invalid %s text at offset %d: %s", e.Type, e.Offset, e.Reason)
+}
+
+// appendFloat appends the shortest representation of v which parses back to
+// the exact same value.
+func appendFloat(b []byte, v float64) []byte {
+	return strconv.AppendFloat(b, v, 'g', -1, 64)
+}
+
+// appendFloats appends the list of values given as arguments separated by
+// commas and enclosed by the open and close strings, which is the format used
+// by the String methods of Point, Size and Rect.
+func appendFloats(b []byte, open string, close string, values ...float64) []byte {
+	b = append(b, open...)
+
+	for i, v := range values {
+		if i != 0 {
+			b = append(b, ", "...)
+		}
+		b = appendFloat(b, v)
+	}
+
+	return append(b, close...)
+}
+
+// unmarshalJSON decodes a JSON value which is either a string holding the text
+// representation of a value, or an object with the fields of its type, which is
+// how the encoding/json package encoded the geometric types before they
+// implemented encoding.TextMarshaler. The fields argument must be a pointer to
+// the value converted to a type without methods.
+func unmarshalJSON(b []byte, text encoding.TextUnmarshaler, fields interface{}) error {
+	if len(b) == 0 || b[0] != '"' {
+		return json.Unmarshal(b, fields)
+	}
+
+	var s string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	return text.UnmarshalText([]byte(s))
+}
+
+// textParser is a simple scanner used to parse the text representations of
+// the geometric types, spaces are allowed between every token.
+type textParser struct {
+	typ  string
+	text string
+	off  int
+}
+
+// parseFloats parses a list of values separated by commas and enclosed
+// by the open and close bytes, and stores them in the values pointed to by
+// the arguments.
+func parseFloats(typ string, text []byte, open byte, close byte, values ...*float64) error {
+	p := textParser{typ: typ, text: string(text)}
+
+	if err := p.expect(open); err != nil {
+		return err
+	}
+
+	for i, v := range values {
+		if i != 0 {
+			if err := p.expect(','); err != nil {
+				return err
+			}
+		}
+
+		f, err := p.float()
+
+		if err != nil {
+			return err
+		}
+
+		*v = f
+	}
+
+	if err := p.expect(close); err != nil {
+		return err
+	}
+
+	return p.end()
+}
+
+func (p *textParser) errorf(format string, args ...interface{}) error {
+	return &TextError{
+		Type:   p.typ,
+		Offset: p.off,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *textParser) skipSpaces() {
+	for p.off < len(p.text) && isSVGSpace(p.text[p.off]) {
+		p.off++
+	}
+}
+
+// peek returns the next byte after skipping spaces, or zero at the end of the
+// text.
+func (p *textParser) peek() byte {
+	p.skipSpaces()
+
+	if p.off == len(p.text) {
+		return 0
+	}
+
+	return p.text[p.off]
+}
+
+func (p *textParser) expect(c byte) error {
+	switch p.peek() {
+	case c:
+		p.off++
+		return nil
+	case 0:
+		return p.errorf("expected %q but reached the end of the text", c)
+	default:
+		return p.errorf("expected %q but found %q", c, p.text[p.off])
+	}
+}
+
+func (p *textParser) end() error {
+	if p.peek() != 0 {
+		return p.errorf("unexpected %q after the end of the value", p.text[p.off])
+	}
+	return nil
+}
+
+// word parses a sequence of letters, which may be empty.
+func (p *textParser) word() string {
+	p.skipSpaces()
+	i := p.off
+
+	for p.off < len(p.text) {
+		if c := p.text[p.off]; (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			break
+		}
+		p.off++
+	}
+
+	return p.text[i:p.off]
+}
+
+func (p *textParser) float() (float64, error) {
+	p.skipSpaces()
+	i := p.off
+
+	for p.off < len(p.text) {
+		if c := p.text[p.off]; isSVGSpace(c) || c == ',' || c == ')' || c == ']' || c == '}' {
+			break
+		}
+		p.off++
+	}
+
+	if i == p.off {
+		return 0, p.errorf("expected a number")
+	}
+
+	v, err := strconv.ParseFloat(p.text[i:p.off], 64)
+
+	if err != nil {
+		s := p.text[i:p.off]
+		p.off = i
+		return 0, p.errorf("invalid number %q", s)
+	}
+
+	return v, nil
+}
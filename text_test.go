@@ -0,0 +1,231 @@
+package geom
+
+import (
+	"encoding"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+	path := Path{}
+	path.MoveTo(Point{0.1, 2})
+	path.LineTo(Point{3, 4})
+	path.Close()
+
+	tests := []struct {
+		value encoding.TextMarshaler
+		text  string
+	}{
+		{Point{1, -2.5}, "(1, -2.5)"},
+		{Point{0.1, 1.0 / 3}, "(0.1, 0.3333333333333333)"},
+		{Size{2, 3}, "[2, 3]"},
+		{Rect{1, 2, 3, 4e-10}, "{ 1, 2, 3, 4e-10 }"},
+		{Margin{1, 2, 3, 4}, "margin { top = 1, bottom = 2, left = 3, right = 4 }"},
+		{path, "M.1 2L3 4Z"},
+	}
+
+	for _, test := range tests {
+		b, err := test.value.MarshalText()
+
+		if err != nil {
+			t.Errorf("%v: %s", test.value, err)
+			continue
+		}
+
+		if s := string(b); s != test.text {
+			t.Errorf("%v: %q != %q", test.value, s, test.text)
+		}
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	path := Path{}
+	path.MoveTo(Point{1, 2})
+	path.LineTo(Point{3, 4})
+
+	tests := []struct {
+		text  string
+		value interface{}
+	}{
+		{"(1, -2.5)", Point{1, -2.5}},
+		{"  ( 1 ,-2.5 )  ", Point{1, -2.5}},
+		{"(1e3, +Inf)", Point{1000, math.Inf(1)}},
+		{"[2, 3]", Size{2, 3}},
+		{"{ 1, 2, 3, 4 }", Rect{1, 2, 3, 4}},
+		{"{1,2,3,4}", Rect{1, 2, 3, 4}},
+		{"margin { top = 1, bottom = 2, left = 3, right = 4 }", Margin{1, 2, 3, 4}},
+		{"margin { right = 4, top = 1 }", Margin{Top: 1, Right: 4}},
+		{"margin {}", Margin{}},
+		{"M1 2L3 4", path},
+	}
+
+	for _, test := range tests {
+		v := reflect.New(reflect.TypeOf(test.value))
+
+		if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(test.text)); err != nil {
+			t.Errorf("%q: %s", test.text, err)
+			continue
+		}
+
+		if x := v.Elem().Interface(); !reflect.DeepEqual(x, test.value) {
+			t.Errorf("%q: %v != %v", test.text, x, test.value)
+		}
+	}
+}
+
+func TestUnmarshalTextError(t *testing.T) {
+	tests := []struct {
+		text   string
+		value  encoding.TextUnmarshaler
+		offset int
+	}{
+		{"", &Point{}, 0},
+		{"1, 2", &Point{}, 0},
+		{"(1 2)", &Point{}, 3},
+		{"(1, 2", &Point{}, 5},
+		{"(1, x)", &Point{}, 4},
+		{"(1, 2) 3", &Point{}, 7},
+		{"(1, 2)", &Size{}, 0},
+		{"[1, 2, 3]", &Size{}, 5},
+		{"{ 1, 2, 3 }", &Rect{}, 10},
+		{"{ 1, 2, 3, 4, 5 }", &Rect{}, 12},
+		{"{ top = 1 }", &Margin{}, 0},
+		{"margin { top = 1, top = 2 }", &Margin{}, 18},
+		{"margin { center = 1 }", &Margin{}, 9},
+		{"margin { top 1 }", &Margin{}, 13},
+		{"margin { top = 1 bottom = 2 }", &Margin{}, 17},
+	}
+
+	for _, test := range tests {
+		err := test.value.UnmarshalText([]byte(test.text))
+		e, ok := err.(*TextError)
+
+		if !ok {
+			t.Errorf("%q: invalid error: %v", test.text, err)
+			continue
+		}
+
+		if e.Offset != test.offset {
+			t.Errorf("%q: invalid error offset: %d != %d (%s)", test.text, e.Offset, test.offset, e)
+		}
+	}
+
+	var p Path
+
+	if _, ok := p.UnmarshalText([]byte("M1 2 X")).(*SVGPathError); !ok {
+		t.Error("invalid path data didn't return an SVG path error")
+	}
+}
+
+func TestTextErrorMessage(t *testing.T) {
+	var p Point
+	err := p.UnmarshalText([]byte("(1, x)"))
+
+	if s := err.Error(); s != `geom: invalid point text at offset 4: invalid number "x"` {
+		t.Error("invalid error message:", s)
+	}
+
+	if !p.Zero() {
+		t.Error("the point was modified by a failed call to UnmarshalText:", p)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	type layout struct {
+		Origin  Point
+		Size    Size
+		Frame   Rect
+		Margin  Margin
+		Outline Path
+		Anchors map[string]Point
+	}
+
+	in := layout{
+		Origin:  Point{0.1, 0.2},
+		Size:    Size{1.0 / 3, 2.0 / 3},
+		Frame:   Rect{1, 2, 3, math.Pi},
+		Margin:  Margin{1, 2, 3, 4},
+		Outline: Circle{Point{1, 1}, 1.0 / 7}.Path(),
+		Anchors: map[string]Point{"center": {math.E, math.Sqrt2}},
+	}
+
+	b, err := json.Marshal(in)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out layout
+
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("%s:\n%+v\n!=\n%+v", b, out, in)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Origin":"(1, 2"}`), &out); err == nil {
+		t.Error("malformed JSON value didn't return an error")
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	type layout struct {
+		Origin  Point
+		Size    Size
+		Frame   Rect
+		Margin  Margin
+		Outline Path
+	}
+
+	path := Path{}
+	path.MoveTo(Point{1, 2})
+	path.LineTo(Point{3, 4})
+
+	in := layout{
+		Origin:  Point{1, 2},
+		Size:    Size{3, 4},
+		Frame:   Rect{1, 2, 3, 4},
+		Margin:  Margin{1, 2, 3, 4},
+		Outline: path,
+	}
+
+	b, err := json.Marshal(in)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const text = `{"Origin":"(1, 2)","Size":"[3, 4]","Frame":"{ 1, 2, 3, 4 }",` +
+		`"Margin":"margin { top = 1, bottom = 2, left = 3, right = 4 }","Outline":"M1 2L3 4"}`
+
+	if s := string(b); s != text {
+		t.Errorf("invalid JSON encoding:\n%s\n!=\n%s", s, text)
+	}
+
+	// Objects are the format that encoding/json used before the types
+	// implemented encoding.TextMarshaler.
+	const objects = `{
+		"Origin": {"X": 1, "Y": 2},
+		"Size": {"W": 3, "H": 4},
+		"Frame": {"X": 1, "Y": 2, "W": 3, "H": 4},
+		"Margin": {"Top": 1, "Bottom": 2, "Left": 3, "Right": 4},
+		"Outline": {"Elements": [
+			{"Type": 0, "Points": [{"X": 1, "Y": 2}, {"X": 0, "Y": 0}, {"X": 0, "Y": 0}]},
+			{"Type": 1, "Points": [{"X": 3, "Y": 4}, {"X": 0, "Y": 0}, {"X": 0, "Y": 0}]}
+		]}
+	}`
+
+	var out layout
+
+	if err := json.Unmarshal([]byte(objects), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("invalid values decoded from JSON objects:\n%+v\n!=\n%+v", out, in)
+	}
+}
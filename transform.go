@@ -0,0 +1,196 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+)
+
+// The Transform type represents a 2D affine transformation. The fields are the
+// six configurable components of a 3x3 matrix laid out as:
+//
+//	| A C E |
+//	| B D F |
+//	| 0 0 1 |
+//
+// A point (x, y) is transformed to (A*x + C*y + E, B*x + D*y + F).
+//
+// The zero-value is not the identity transform (it maps every point to the
+// origin), programs should use the Identity function to get a transform that
+// leaves values unchanged.
+type Transform struct {
+	A float64
+	B float64
+	C float64
+	D float64
+	E float64
+	F float64
+}
+
+// Identity returns the transform that leaves every value unchanged.
+func Identity() Transform {
+	return Transform{A: 1, D: 1}
+}
+
+// Translation returns a transform that moves values by tx on the x axis and ty
+// on the y axis.
+func Translation(tx float64, ty float64) Transform {
+	return Transform{A: 1, D: 1, E: tx, F: ty}
+}
+
+// Scaling returns a transform that scales values by sx on the x axis and sy on
+// the y axis, relative to the origin.
+func Scaling(sx float64, sy float64) Transform {
+	return Transform{A: sx, D: sy}
+}
+
+// Rotation returns a transform that rotates values around the origin by the
+// angle given as argument, in radians.
+func Rotation(angle float64) Transform {
+	sin, cos := math.Sincos(angle)
+	return Transform{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// Skewing returns a transform that skews values by ax along the x axis and ay
+// along the y axis, both angles are expressed in radians.
+func Skewing(ax float64, ay float64) Transform {
+	return Transform{A: 1, B: math.Tan(ay), C: math.Tan(ax), D: 1}
+}
+
+// Concat returns the transform that applies the receiver first, then the one
+// given as argument.
+func (t Transform) Concat(t1 Transform) Transform {
+	return Transform{
+		A: t1.A*t.A + t1.C*t.B,
+		B: t1.B*t.A + t1.D*t.B,
+		C: t1.A*t.C + t1.C*t.D,
+		D: t1.B*t.C + t1.D*t.D,
+		E: t1.A*t.E + t1.C*t.F + t1.E,
+		F: t1.B*t.E + t1.D*t.F + t1.F,
+	}
+}
+
+// Translate returns a transform that applies the receiver, then a translation
+// by tx and ty.
+func (t Transform) Translate(tx float64, ty float64) Transform {
+	return t.Concat(Translation(tx, ty))
+}
+
+// Scale returns a transform that applies the receiver, then a scaling by sx and
+// sy.
+func (t Transform) Scale(sx float64, sy float64) Transform {
+	return t.Concat(Scaling(sx, sy))
+}
+
+// Rotate returns a transform that applies the receiver, then a rotation by the
+// angle given as argument (in radians).
+func (t Transform) Rotate(angle float64) Transform {
+	return t.Concat(Rotation(angle))
+}
+
+// Skew returns a transform that applies the receiver, then a skew by ax and ay
+// (in radians).
+func (t Transform) Skew(ax float64, ay float64) Transform {
+	return t.Concat(Skewing(ax, ay))
+}
+
+// Determinant computes and returns the determinant of the transform's matrix.
+func (t Transform) Determinant() float64 {
+	return t.A*t.D - t.B*t.C
+}
+
+// Invert computes the inverse of the transform it is called on. The boolean
+// returned by the method is false if the transform is not invertible (its
+// determinant is zero), in which case the returned transform is the
+// zero-value.
+func (t Transform) Invert() (Transform, bool) {
+	det := t.Determinant()
+
+	if det == 0 {
+		return Transform{}, false
+	}
+
+	return Transform{
+		A: t.D / det,
+		B: -t.B / det,
+		C: -t.C / det,
+		D: t.A / det,
+		E: (t.C*t.F - t.D*t.E) / det,
+		F: (t.B*t.E - t.A*t.F) / det,
+	}, true
+}
+
+// IsIdentity checks whether the transform is the identity transform.
+func (t Transform) IsIdentity() bool {
+	return t == Identity()
+}
+
+// ApplyPoint transforms the point given as argument and returns the result.
+func (t Transform) ApplyPoint(p Point) Point {
+	return Point{
+		X: t.A*p.X + t.C*p.Y + t.E,
+		Y: t.B*p.X + t.D*p.Y + t.F,
+	}
+}
+
+// ApplySize transforms the size given as argument, interpreting it as a vector
+// which means the translation components of the transform are ignored.
+func (t Transform) ApplySize(s Size) Size {
+	return Size{
+		W: t.A*s.W + t.C*s.H,
+		H: t.B*s.W + t.D*s.H,
+	}
+}
+
+// ApplyRect transforms the four corners of the rectangle given as argument and
+// returns the smallest rectangle that contains all of them.
+func (t Transform) ApplyRect(r Rect) Rect {
+	r = r.Abs()
+
+	p0 := t.ApplyPoint(Point{r.X, r.Y})
+	p1 := t.ApplyPoint(Point{r.X + r.W, r.Y})
+	p2 := t.ApplyPoint(Point{r.X + r.W, r.Y + r.H})
+	p3 := t.ApplyPoint(Point{r.X, r.Y + r.H})
+
+	x0 := math.Min(math.Min(p0.X, p1.X), math.Min(p2.X, p3.X))
+	y0 := math.Min(math.Min(p0.Y, p1.Y), math.Min(p2.Y, p3.Y))
+	x1 := math.Max(math.Max(p0.X, p1.X), math.Max(p2.X, p3.X))
+	y1 := math.Max(math.Max(p0.Y, p1.Y), math.Max(p2.Y, p3.Y))
+
+	return Rect{
+		X: x0,
+		Y: y0,
+		W: x1 - x0,
+		H: y1 - y0,
+	}
+}
+
+// ApplyPath transforms every point of the path given as argument and returns
+// the result as a new Path value, the original path is not modified.
+func (t Transform) ApplyPath(p Path) Path {
+	p = p.Copy()
+
+	for i := range p.Elements {
+		e := &p.Elements[i]
+
+		switch e.Type {
+		case MoveTo, LineTo:
+			e.Points[0] = t.ApplyPoint(e.Points[0])
+
+		case QuadCurveTo:
+			e.Points[0] = t.ApplyPoint(e.Points[0])
+			e.Points[1] = t.ApplyPoint(e.Points[1])
+
+		case CubicCurveTo:
+			e.Points[0] = t.ApplyPoint(e.Points[0])
+			e.Points[1] = t.ApplyPoint(e.Points[1])
+			e.Points[2] = t.ApplyPoint(e.Points[2])
+		}
+	}
+
+	return p
+}
+
+// The String method returns a human-readable representation of the transform.
+func (t Transform) String() string {
+	return fmt.Sprintf("matrix(%.6g, %.6g, %.6g, %.6g, %.6g, %.6g)", t.A, t.B, t.C, t.D, t.E, t.F)
+}
@@ -0,0 +1,129 @@
+package geom
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func nearlyEqual(a float64, b float64) bool {
+	return math.Abs(a-b) <= epsilon
+}
+
+func pointsNearlyEqual(p1 Point, p2 Point) bool {
+	return nearlyEqual(p1.X, p2.X) && nearlyEqual(p1.Y, p2.Y)
+}
+
+func rectsNearlyEqual(r1 Rect, r2 Rect) bool {
+	return nearlyEqual(r1.X, r2.X) && nearlyEqual(r1.Y, r2.Y) && nearlyEqual(r1.W, r2.W) && nearlyEqual(r1.H, r2.H)
+}
+
+func TestIdentity(t *testing.T) {
+	m := Identity()
+
+	if !m.IsIdentity() {
+		t.Error("identity transform is not the identity:", m)
+	}
+
+	if p := m.ApplyPoint(Point{1, 2}); p != (Point{1, 2}) {
+		t.Error("identity transform modified a point:", p)
+	}
+}
+
+func TestTranslation(t *testing.T) {
+	if p := Translation(1, -1).ApplyPoint(Point{1, 2}); p != (Point{2, 1}) {
+		t.Error("invalid translated point:", p)
+	}
+}
+
+func TestScaling(t *testing.T) {
+	if p := Scaling(2, 3).ApplyPoint(Point{1, 2}); p != (Point{2, 6}) {
+		t.Error("invalid scaled point:", p)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	if p := Rotation(math.Pi / 2).ApplyPoint(Point{1, 0}); !pointsNearlyEqual(p, Point{0, 1}) {
+		t.Error("invalid rotated point:", p)
+	}
+}
+
+func TestSkewing(t *testing.T) {
+	if p := Skewing(math.Pi/4, 0).ApplyPoint(Point{0, 1}); !pointsNearlyEqual(p, Point{1, 1}) {
+		t.Error("invalid skewed point:", p)
+	}
+}
+
+func TestTransformConcat(t *testing.T) {
+	m := Identity().Scale(2, 2).Translate(1, 0).Rotate(math.Pi)
+
+	if p := m.ApplyPoint(Point{1, 1}); !pointsNearlyEqual(p, Point{-3, -2}) {
+		t.Error("invalid point transformed by a composed transform:", m, p)
+	}
+}
+
+func TestTransformInvert(t *testing.T) {
+	m := Identity().Rotate(0.5).Scale(2, 3).Translate(4, 5).Skew(0.1, 0.2)
+	i, ok := m.Invert()
+
+	if !ok {
+		t.Error("invertible transform could not be inverted:", m)
+		return
+	}
+
+	if p := i.ApplyPoint(m.ApplyPoint(Point{1, 2})); !pointsNearlyEqual(p, Point{1, 2}) {
+		t.Error("inverse transform did not restore the original point:", p)
+	}
+}
+
+func TestTransformInvertSingular(t *testing.T) {
+	if _, ok := Scaling(0, 1).Invert(); ok {
+		t.Error("singular transform must not be invertible")
+	}
+}
+
+func TestTransformApplySize(t *testing.T) {
+	if s := Translation(10, 10).Scale(2, 3).ApplySize(Size{1, 1}); s != (Size{2, 3}) {
+		t.Error("invalid transformed size:", s)
+	}
+}
+
+func TestTransformApplyRect(t *testing.T) {
+	r := Rotation(math.Pi / 4).ApplyRect(Rect{0, 0, 1, 1})
+	h := math.Sqrt2 / 2
+
+	if !rectsNearlyEqual(r, Rect{-h, 0, 2 * h, 2 * h}) {
+		t.Error("invalid bounding rectangle of a transformed rectangle:", r)
+	}
+}
+
+func TestTransformApplyPath(t *testing.T) {
+	p := Path{}
+	p.MoveTo(Point{1, 1})
+	p.QuadCurveTo(Point{2, 2}, Point{3, 3})
+	p.CubicCurveTo(Point{4, 4}, Point{5, 5}, Point{6, 6})
+	p.Close()
+
+	q := Translation(1, 2).ApplyPath(p)
+
+	if !reflect.DeepEqual(q.Elements, []PathElement{
+		{Type: MoveTo, Points: [...]Point{{2, 3}, {}, {}}},
+		{Type: QuadCurveTo, Points: [...]Point{{3, 4}, {4, 5}, {}}},
+		{Type: CubicCurveTo, Points: [...]Point{{5, 6}, {6, 7}, {7, 8}}},
+		{Type: ClosePath},
+	}) {
+		t.Errorf("invalid transformed path: %#v", q)
+	}
+
+	if p.Elements[0].Points[0] != (Point{1, 1}) {
+		t.Error("applying a transform modified the original path:", p)
+	}
+}
+
+func TestTransformString(t *testing.T) {
+	if s := Identity().String(); s != "matrix(1, 0, 0, 1, 0, 0)" {
+		t.Error("invalid string representation of a transform:", s)
+	}
+}